@@ -11,6 +11,8 @@ func buildAliasCommands(p *porter.Porter) []*cobra.Command {
 	return []*cobra.Command{
 		buildCreateAlias(p),
 		buildBuildAlias(p),
+		buildValidateAlias(p),
+		buildLintAlias(p),
 		buildInstallAlias(p),
 		buildUpgradeAlias(p),
 		buildUninstallAlias(p),
@@ -43,6 +45,24 @@ func buildBuildAlias(p *porter.Porter) *cobra.Command {
 	return cmd
 }
 
+func buildValidateAlias(p *porter.Porter) *cobra.Command {
+	cmd := buildBundleValidateCommand(p)
+	cmd.Example = strings.Replace(cmd.Example, "porter bundle validate", "porter validate", -1)
+	cmd.Annotations = map[string]string{
+		"group": "alias",
+	}
+	return cmd
+}
+
+func buildLintAlias(p *porter.Porter) *cobra.Command {
+	cmd := buildBundleLintCommand(p)
+	cmd.Example = strings.Replace(cmd.Example, "porter bundle lint", "porter lint", -1)
+	cmd.Annotations = map[string]string{
+		"group": "alias",
+	}
+	return cmd
+}
+
 func buildInstallAlias(p *porter.Porter) *cobra.Command {
 	cmd := buildBundleInstallCommand(p)
 	cmd.Example = strings.Replace(cmd.Example, "porter bundle install", "porter install", -1)
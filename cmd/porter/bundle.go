@@ -19,6 +19,8 @@ func buildBundleCommands(p *porter.Porter) *cobra.Command {
 
 	cmd.AddCommand(buildBundleCreateCommand(p))
 	cmd.AddCommand(buildBundleBuildCommand(p))
+	cmd.AddCommand(buildBundleValidateCommand(p))
+	cmd.AddCommand(buildBundleLintCommand(p))
 	cmd.AddCommand(buildBundleInstallCommand(p))
 	cmd.AddCommand(buildBundleUpgradeCommand(p))
 	cmd.AddCommand(buildBundleInvokeCommand(p))
@@ -54,7 +56,40 @@ func buildBundleBuildCommand(p *porter.Porter) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Enable verbose logging")
+	f := cmd.Flags()
+	f.BoolVarP(&opts.Verbose, "verbose", "v", false, "Enable verbose logging")
+	f.BoolVar(&opts.AllowMissingSchemas, "allow-missing-schemas", false,
+		"Skip validating the steps of mixins that do not provide a schema")
+
+	return cmd
+}
+
+func buildBundleValidateCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.ValidateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a porter manifest",
+		Long: `Validate a porter manifest, and the files that it includes, against the porter manifest schema combined with the schema of each mixin.
+
+Each problem is reported with the file and line where it occurs. Validating a mixin's steps requires the mixin to be installed and to provide a schema, use --allow-missing-schemas to skip validating the steps of mixins without a schema.`,
+		Example: `  porter bundle validate
+  porter bundle validate --file myapp/porter.yaml
+  porter bundle validate --allow-missing-schemas
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(p.Context)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ValidateManifest(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.File, "file", "f", "",
+		"Path to the porter manifest file. Defaults to the bundle in the current directory.")
+	f.BoolVar(&opts.AllowMissingSchemas, "allow-missing-schemas", false,
+		"Skip validating the steps of mixins that do not provide a schema")
 
 	return cmd
 }
@@ -74,9 +109,13 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
   porter bundle install --insecure
   porter bundle install MyAppInDev --file myapp/bundle.json
   porter bundle install --param-file base-values.txt --param-file dev-values.txt --param test-mode=true --param header-color=blue
+  porter bundle install --parameter-set azure --parameter-set dev
   porter bundle install --cred azure --cred kubernetes
   porter bundle install --driver debug
   porter bundle install MyAppFromTag --tag getporter/kubernetes:v0.1.0
+  porter bundle install --dependency-installation mysql=shared-mysql
+  porter bundle install --resume
+  porter bundle install --interactive
 `,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return opts.Validate(args, p.Context)
@@ -94,9 +133,11 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
 	f.StringVar(&opts.CNABFile, "cnab-file", "",
 		"Path to the CNAB bundle.json file.")
 	f.StringSliceVar(&opts.ParamFiles, "param-file", nil,
-		"Path to a parameters definition file for the bundle, each line in the form of NAME=VALUE. May be specified multiple times.")
+		"Path to a parameters definition file for the bundle. YAML (.yaml, .yml) and JSON (.json) files define a map of parameter names to values, other files define each parameter on a line in the form of NAME=VALUE. May be specified multiple times.")
 	f.StringSliceVar(&opts.Params, "param", nil,
 		"Define an individual parameter in the form NAME=VALUE. Overrides parameters set with the same name using --param-file. May be specified multiple times.")
+	f.StringSliceVar(&opts.ParameterSets, "parameter-set", nil,
+		"Name of a parameter set for the bundle. Parameters set with the same name using --param-file or --param take precedence. May be specified multiple times.")
 	f.StringSliceVarP(&opts.CredentialIdentifiers, "cred", "c", nil,
 		"Credential to use when installing the bundle. May be either a named set of credentials or a filepath, and specified multiple times.")
 	f.StringVarP(&opts.Driver, "driver", "d", porter.DefaultDriver,
@@ -107,6 +148,14 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
 		"Don't require TLS for the registry")
 	f.BoolVar(&opts.Force, "force", false,
 		"Force a fresh pull of the bundle and all dependencies")
+	f.BoolVar(&opts.SkipDependencies, "skip-dependencies", false,
+		"Do not execute the action against the bundle's dependencies, any existing dependency installations are left untouched")
+	f.StringSliceVar(&opts.DependencyInstallations, "dependency-installation", nil,
+		"Use an existing installation for a dependency instead of installing a new one, in the form ALIAS=INSTALLATION. May be specified multiple times.")
+	f.BoolVar(&opts.Resume, "resume", false,
+		"Resume the last failed run of the action, skipping the steps and dependencies that already completed")
+	f.BoolVar(&opts.Interactive, "interactive", false,
+		"Prompt for the value of each parameter that was not specified, the value is validated against the parameter's definition")
 	return cmd
 }
 
@@ -125,9 +174,12 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
   porter bundle upgrade --insecure
   porter bundle upgrade MyAppInDev --file myapp/bundle.json
   porter bundle upgrade --param-file base-values.txt --param-file dev-values.txt --param test-mode=true --param header-color=blue
+  porter bundle upgrade --parameter-set azure --parameter-set dev
   porter bundle upgrade --cred azure --cred kubernetes
   porter bundle upgrade --driver debug
   porter bundle upgrade MyAppFromTag --tag getporter/kubernetes:v0.1.0
+  porter bundle upgrade --resume
+  porter bundle upgrade --interactive
 `,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return opts.Validate(args, p.Context)
@@ -145,9 +197,11 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
 	f.StringVar(&opts.CNABFile, "cnab-file", "",
 		"Path to the CNAB bundle.json file.")
 	f.StringSliceVar(&opts.ParamFiles, "param-file", nil,
-		"Path to a parameters definition file for the bundle, each line in the form of NAME=VALUE. May be specified multiple times.")
+		"Path to a parameters definition file for the bundle. YAML (.yaml, .yml) and JSON (.json) files define a map of parameter names to values, other files define each parameter on a line in the form of NAME=VALUE. May be specified multiple times.")
 	f.StringSliceVar(&opts.Params, "param", nil,
 		"Define an individual parameter in the form NAME=VALUE. Overrides parameters set with the same name using --param-file. May be specified multiple times.")
+	f.StringSliceVar(&opts.ParameterSets, "parameter-set", nil,
+		"Name of a parameter set for the bundle. Parameters set with the same name using --param-file or --param take precedence. May be specified multiple times.")
 	f.StringSliceVarP(&opts.CredentialIdentifiers, "cred", "c", nil,
 		"Credential to use when installing the bundle. May be either a named set of credentials or a filepath, and specified multiple times.")
 	f.StringVarP(&opts.Driver, "driver", "d", porter.DefaultDriver,
@@ -158,6 +212,14 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
 		"Don't require TLS for the registry")
 	f.BoolVar(&opts.Force, "force", false,
 		"Force a fresh pull of the bundle and all dependencies")
+	f.BoolVar(&opts.SkipDependencies, "skip-dependencies", false,
+		"Do not execute the action against the bundle's dependencies, any existing dependency installations are left untouched")
+	f.StringSliceVar(&opts.DependencyInstallations, "dependency-installation", nil,
+		"Use an existing installation for a dependency instead of installing a new one, in the form ALIAS=INSTALLATION. May be specified multiple times.")
+	f.BoolVar(&opts.Resume, "resume", false,
+		"Resume the last failed run of the action, skipping the steps and dependencies that already completed")
+	f.BoolVar(&opts.Interactive, "interactive", false,
+		"Prompt for the value of each parameter that was not specified, the value is validated against the parameter's definition")
 
 	return cmd
 }
@@ -176,9 +238,11 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
 		Example: `  porter bundle invoke --action ACTION
   porter bundle invoke --action ACTION MyAppInDev --file myapp/bundle.json
   porter bundle invoke --action ACTION --param-file base-values.txt --param-file dev-values.txt --param test-mode=true --param header-color=blue
+  porter bundle invoke --action ACTION --parameter-set azure --parameter-set dev
   porter bundle invoke --action ACTION --cred azure --cred kubernetes
   porter bundle invoke --action ACTION --driver debug
   porter bundle invoke --action ACTION MyAppFromTag --tag getporter/kubernetes:v0.1.0
+  porter bundle invoke --action ACTION --interactive
 `,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return opts.Validate(args, p.Context)
@@ -196,9 +260,11 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
 	f.StringVar(&opts.CNABFile, "cnab-file", "",
 		"Path to the CNAB bundle.json file.")
 	f.StringSliceVar(&opts.ParamFiles, "param-file", nil,
-		"Path to a parameters definition file for the bundle, each line in the form of NAME=VALUE. May be specified multiple times.")
+		"Path to a parameters definition file for the bundle. YAML (.yaml, .yml) and JSON (.json) files define a map of parameter names to values, other files define each parameter on a line in the form of NAME=VALUE. May be specified multiple times.")
 	f.StringSliceVar(&opts.Params, "param", nil,
 		"Define an individual parameter in the form NAME=VALUE. Overrides parameters set with the same name using --param-file. May be specified multiple times.")
+	f.StringSliceVar(&opts.ParameterSets, "parameter-set", nil,
+		"Name of a parameter set for the bundle. Parameters set with the same name using --param-file or --param take precedence. May be specified multiple times.")
 	f.StringSliceVarP(&opts.CredentialIdentifiers, "cred", "c", nil,
 		"Credential to use when installing the bundle. May be either a named set of credentials or a filepath, and specified multiple times.")
 	f.StringVarP(&opts.Driver, "driver", "d", porter.DefaultDriver,
@@ -209,6 +275,10 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
 		"Don't require TLS for the registry")
 	f.BoolVar(&opts.Force, "force", false,
 		"Force a fresh pull of the bundle and all dependencies")
+	f.BoolVar(&opts.SkipDependencies, "skip-dependencies", false,
+		"Do not execute the action against the bundle's dependencies, any existing dependency installations are left untouched")
+	f.BoolVar(&opts.Interactive, "interactive", false,
+		"Prompt for the value of each parameter that was not specified, the value is validated against the parameter's definition")
 
 	return cmd
 }
@@ -228,6 +298,7 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
   porter bundle uninstall --insecure
   porter bundle uninstall MyAppInDev --file myapp/bundle.json
   porter bundle uninstall --param-file base-values.txt --param-file dev-values.txt --param test-mode=true --param header-color=blue
+  porter bundle uninstall --parameter-set azure --parameter-set dev
   porter bundle uninstall --cred azure --cred kubernetes
   porter bundle uninstall --driver debug
   porter bundle uninstall MyAppFromTag --tag getporter/kubernetes:v0.1.0
@@ -249,9 +320,11 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
 	f.StringVar(&opts.CNABFile, "cnab-file", "",
 		"Path to the CNAB bundle.json file.")
 	f.StringSliceVar(&opts.ParamFiles, "param-file", nil,
-		"Path to a parameters definition file for the bundle, each line in the form of NAME=VALUE. May be specified multiple times.")
+		"Path to a parameters definition file for the bundle. YAML (.yaml, .yml) and JSON (.json) files define a map of parameter names to values, other files define each parameter on a line in the form of NAME=VALUE. May be specified multiple times.")
 	f.StringSliceVar(&opts.Params, "param", nil,
 		"Define an individual parameter in the form NAME=VALUE. Overrides parameters set with the same name using --param-file. May be specified multiple times.")
+	f.StringSliceVar(&opts.ParameterSets, "parameter-set", nil,
+		"Name of a parameter set for the bundle. Parameters set with the same name using --param-file or --param take precedence. May be specified multiple times.")
 	f.StringSliceVarP(&opts.CredentialIdentifiers, "cred", "c", nil,
 		"Credential to use when uninstalling the bundle. May be either a named set of credentials or a filepath, and specified multiple times.")
 	f.StringVarP(&opts.Driver, "driver", "d", porter.DefaultDriver,
@@ -262,6 +335,8 @@ For example, the 'debug' driver may be specified, which simply logs the info giv
 		"Don't require TLS for the registry")
 	f.BoolVar(&opts.Force, "force", false,
 		"Force a fresh pull of the bundle and all dependencies")
+	f.BoolVar(&opts.SkipDependencies, "skip-dependencies", false,
+		"Do not execute the action against the bundle's dependencies, any existing dependency installations are left untouched")
 
 	return cmd
 }
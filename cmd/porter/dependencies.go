@@ -0,0 +1,93 @@
+package main
+
+import (
+	"get.porter.sh/porter/pkg/porter"
+	"github.com/spf13/cobra"
+)
+
+func buildDependenciesCommands(p *porter.Porter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dependencies",
+		Aliases: []string{"dependency", "deps"},
+		Short:   "Dependency commands",
+		Long:    "Commands for working with the dependencies of a bundle",
+	}
+	cmd.Annotations = map[string]string{
+		"group": "resource",
+	}
+
+	cmd.AddCommand(buildDependenciesTreeCommand(p))
+	cmd.AddCommand(buildDependenciesUpdateCommand(p))
+
+	return cmd
+}
+
+func buildDependenciesUpdateCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.DependencyUpdateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update the locked dependency versions",
+		Long: `Update the locked dependency versions of a bundle.
+
+When a bundle is built, Porter records the version of each dependency, including dependencies of dependencies, in a porter.lock file next to the porter manifest. Subsequent builds reuse the locked versions, so that the bundle is reproducible.
+
+This command resolves the dependencies of the bundle again, ignoring the locked versions, and saves the results to porter.lock. Build the bundle afterwards to use the updated versions.`,
+		Example: `  porter dependencies update
+  porter dependencies update --file myapp/porter.yaml
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(p.Context)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.UpdateDependencies(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.File, "file", "f", "",
+		"Path to the porter manifest file. Defaults to the bundle in the current directory.")
+	f.BoolVar(&opts.InsecureRegistry, "insecure-registry", false,
+		"Don't require TLS for the registry")
+
+	return cmd
+}
+
+func buildDependenciesTreeCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.DependencyTreeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "tree",
+		Short: "Print the dependency tree of a bundle",
+		Long: `Print the resolved dependency tree of a bundle, including the dependencies of its dependencies.
+
+Each dependency is listed with its alias, the requested bundle and version ranges, and the tag and digest that it resolved to. When the bundle has locked dependency versions, either in porter.lock or in the bundle.json, the locked versions are used.`,
+		Example: `  porter dependencies tree
+  porter dependencies tree --file myapp/porter.yaml
+  porter dependencies tree --cnab-file myapp/.cnab/bundle.json
+  porter dependencies tree --tag getporter/wordpress:v0.1.0 --output json
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(p.Context)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.PrintDependencyTree(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.File, "file", "f", "",
+		"Path to the porter manifest file. Defaults to the bundle in the current directory.")
+	f.StringVar(&opts.CNABFile, "cnab-file", "",
+		"Path to the CNAB bundle.json file.")
+	f.StringVarP(&opts.Tag, "tag", "t", "",
+		"Use a bundle in an OCI registry specified by the given tag")
+	f.BoolVar(&opts.InsecureRegistry, "insecure-registry", false,
+		"Don't require TLS for the registry")
+	f.BoolVar(&opts.Force, "force", false,
+		"Force a fresh pull of the bundle and all dependencies")
+	f.StringVarP(&opts.RawFormat, "output", "o", "table",
+		"Specify an output format.  Allowed values: table, json, yaml")
+
+	return cmd
+}
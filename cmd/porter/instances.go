@@ -18,6 +18,10 @@ func buildInstanceCommands(p *porter.Porter) *cobra.Command {
 
 	cmd.AddCommand(buildInstancesListCommand(p))
 	cmd.AddCommand(buildInstanceShowCommand(p))
+	cmd.AddCommand(buildInstanceHistoryCommand(p))
+	cmd.AddCommand(buildInstanceLogsCommand(p))
+	cmd.AddCommand(buildInstanceRollbackCommand(p))
+	cmd.AddCommand(buildInstanceStatusCommand(p))
 
 	cmd.AddCommand(buildInstanceOutputsCommands(p))
 
@@ -78,3 +82,133 @@ Optional output formats include json and yaml.
 
 	return &cmd
 }
+
+func buildInstanceHistoryCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.HistoryOptions{}
+
+	cmd := cobra.Command{
+		Use:   "history [INSTANCE]",
+		Short: "Show the history of an instance of a bundle",
+		Long: `Show every action that was executed against an instance of a bundle, from oldest to newest.
+
+Each run records the action, when it completed, its status, the version of the bundle and of Porter, the user that executed it, and the parameters and outputs of the run. The values of sensitive parameters and outputs are redacted. The history of an instance is kept after it is uninstalled.
+
+Optional output formats include json and yaml, which include the parameters and outputs of each run.`,
+		Example: `  porter instance history
+  porter instance history another-bundle
+  porter instance history another-bundle -o json
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args, p.Context)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ShowInstanceHistory(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.RawFormat, "output", "o", "table",
+		"Specify an output format.  Allowed values: table, json, yaml")
+
+	return &cmd
+}
+
+func buildInstanceLogsCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.LogsOptions{}
+
+	cmd := cobra.Command{
+		Use:   "logs [INSTANCE]",
+		Short: "Show the logs from a run of an instance of a bundle",
+		Long: `Show the logs from a run of an instance of a bundle, which defaults to the last run.
+
+The logs are the output of the bundle, which Porter saves with the history of the instance every time an action is executed. The values of credentials and sensitive parameters are censored. Use 'porter instances history' to find the ID of a previous run.`,
+		Example: `  porter instance logs
+  porter instance logs another-bundle
+  porter instance logs another-bundle --run 01E2ZZ0P8K9S3N4YF2V4QX7Z6T
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args, p.Context)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ShowInstanceLogs(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&opts.RunID, "run", "",
+		"ID of the run to show the logs from. Defaults to the last run of the instance.")
+
+	return &cmd
+}
+
+func buildInstanceRollbackCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.RollbackOptions{}
+
+	cmd := cobra.Command{
+		Use:   "rollback [INSTANCE]",
+		Short: "Rollback an instance of a bundle to a previous run",
+		Long: `Rollback an instance of a bundle to the state of a previous successful install or upgrade.
+
+The instance is upgraded with the bundle and the parameters that were used by the previous run, which defaults to the last successful install or upgrade before the last run of the instance. Use 'porter instances history' to find the ID of a run to rollback to with --to.
+
+The credentials are not saved with the history of the instance, and are resolved again. The rollback is recorded in the history of the instance.`,
+		Example: `  porter instance rollback
+  porter instance rollback another-bundle --to 01E2ZZ0P8K9S3N4YF2V4QX7Z6T
+  porter instance rollback another-bundle --cred azure --cred kubernetes
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args, p.Context)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.RollbackInstance(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&opts.To, "to", "",
+		"ID of the run to rollback to. Defaults to the last successful install or upgrade before the last run of the instance.")
+	f.StringSliceVarP(&opts.CredentialIdentifiers, "cred", "c", nil,
+		"Credential to use when upgrading the bundle. May be either a named set of credentials or a filepath, and specified multiple times.")
+	f.StringVarP(&opts.Driver, "driver", "d", porter.DefaultDriver,
+		"Specify a driver to use. Allowed values: docker, debug")
+
+	return &cmd
+}
+
+func buildInstanceStatusCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.StatusOptions{}
+
+	cmd := cobra.Command{
+		Use:   "status [INSTANCE]",
+		Short: "Check an instance of a bundle for drift",
+		Long: `Check the live status of an instance of a bundle, and detect drift from the outputs recorded for the instance.
+
+The status custom action of the bundle is executed with the parameters of the instance. The action must not modify resources or be stateless, and the instance is not updated. The outputs that the action reports are compared with the outputs recorded for the instance, and outputs with a different value, or that the action no longer reports, are drift. Outputs that were not recorded, such as health checks, are reported without being compared.
+
+Use --all to check every instance. Instances of bundles that do not define a status action are reported as unsupported.
+
+Optional output formats include json and yaml.`,
+		Example: `  porter instance status
+  porter instance status another-bundle --cred kubernetes
+  porter instance status --all --cred kubernetes -o json
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args, p.Context)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ShowInstanceStatus(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.BoolVar(&opts.All, "all", false,
+		"Check the status of every instance.")
+	f.StringSliceVarP(&opts.CredentialIdentifiers, "cred", "c", nil,
+		"Credential to use when executing the status action. May be either a named set of credentials or a filepath, and specified multiple times.")
+	f.StringVarP(&opts.Driver, "driver", "d", porter.DefaultDriver,
+		"Specify a driver to use. Allowed values: docker, debug")
+	f.StringVarP(&opts.RawFormat, "output", "o", "table",
+		"Specify an output format.  Allowed values: table, json, yaml")
+
+	return &cmd
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"get.porter.sh/porter/pkg/porter"
+	"github.com/spf13/cobra"
+)
+
+func buildBundleLintCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.LintOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Lint a bundle",
+		Long: `Check a bundle for common problems, such as a parameter that is not used by any step, or a reference to an output that is not defined.
+
+Each problem is reported with a level, either error or warning, and the code of the rule that found it. The command fails when any errors are found.`,
+		Example: `  porter bundle lint
+  porter bundle lint --file myapp/porter.yaml
+  porter bundle lint --output json
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(p.Context)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.PrintLintResults(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.File, "file", "f", "",
+		"Path to the porter manifest file. Defaults to the bundle in the current directory.")
+	f.StringVarP(&opts.RawFormat, "output", "o", "table",
+		"Specify an output format.  Allowed values: table, json, yaml, plaintext")
+
+	return cmd
+}
@@ -56,6 +56,8 @@ func buildRootCommand() *cobra.Command {
 	cmd.AddCommand(buildMixinCommands(p))
 	cmd.AddCommand(buildPluginsCommands(p))
 	cmd.AddCommand(buildCredentialsCommands(p))
+	cmd.AddCommand(buildParametersCommands(p))
+	cmd.AddCommand(buildDependenciesCommands(p))
 
 	for _, alias := range buildAliasCommands(p) {
 		cmd.AddCommand(alias)
@@ -11,6 +11,8 @@ func TestCommandWiring(t *testing.T) {
 	testcases := []string{
 		"build",
 		"create",
+		"validate",
+		"lint",
 		"install",
 		"uninstall",
 		"run",
@@ -18,11 +20,24 @@ func TestCommandWiring(t *testing.T) {
 		"bundles",
 		"bundle create",
 		"bundle build",
+		"bundle validate",
+		"bundle lint",
 		"bundle install",
 		"bundle uninstall",
 		"mixins",
 		"mixins list",
 		"plugins list",
+		"parameters create",
+		"parameters list",
+		"parameters show",
+		"parameters edit",
+		"parameters delete",
+		"instances history",
+		"instances logs",
+		"instances rollback",
+		"instances status",
+		"dependencies tree",
+		"dependencies update",
 		"version",
 	}
 
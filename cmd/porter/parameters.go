@@ -0,0 +1,160 @@
+package main
+
+import (
+	"get.porter.sh/porter/pkg/porter"
+	"github.com/spf13/cobra"
+)
+
+func buildParametersCommands(p *porter.Porter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "parameters",
+		Aliases:     []string{"parameter", "param", "params"},
+		Annotations: map[string]string{"group": "resource"},
+		Short:       "Parameter set commands",
+	}
+
+	cmd.AddCommand(buildParametersCreateCommand(p))
+	cmd.AddCommand(buildParametersDeleteCommand(p))
+	cmd.AddCommand(buildParametersEditCommand(p))
+	cmd.AddCommand(buildParametersListCommand(p))
+	cmd.AddCommand(buildParametersShowCommand(p))
+
+	return cmd
+}
+
+func buildParametersCreateCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.ParameterOptions{}
+	cmd := &cobra.Command{
+		Use:   "create [NAME]",
+		Short: "Create a Parameter Set",
+		Long: `Create a named set of parameters for a bundle.
+
+The first argument is the name of parameter set you wish to create. If not
+provided, this will default to the bundle name. By default, Porter will
+create a parameter set for the bundle in the current directory. You may also
+specify a bundle with --file.
+
+A parameter set maps each parameter of the bundle to the source of its value,
+such as an environment variable, a file, a command, a secret or a specific
+value. When you wish to install, upgrade, invoke or uninstall a bundle,
+reference the parameter set with --parameter-set and Porter will read the
+value of each parameter from its source.`,
+		Example: `  porter parameters create
+  porter parameters create dev --file myapp/porter.yaml
+  porter parameters create dev --tag getporter/porter-hello:v0.1.0
+  porter parameters create dev --cnab-file myapp/bundle.json --dry-run
+  porter parameters create dev --silent
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args, p.Context)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.CreateParameters(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.BoolVar(&opts.Insecure, "insecure", true,
+		"Allow working with untrusted bundles.")
+	f.StringVarP(&opts.File, "file", "f", "",
+		"Path to the porter manifest file. Defaults to the bundle in the current directory.")
+	f.StringVar(&opts.CNABFile, "cnab-file", "",
+		"Path to the CNAB bundle.json file.")
+	f.BoolVar(&opts.DryRun, "dry-run", false,
+		"Create the parameter set but do not save it.")
+	f.BoolVar(&opts.Silent, "silent", false,
+		"Create the parameter set without asking for the source of each parameter, the sources must be edited before the set is used.")
+	f.StringVar(&opts.Tag, "tag", "",
+		"Use a bundle in an OCI registry specified by the given tag.")
+	return cmd
+}
+
+func buildParametersDeleteCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.ParameterDeleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "delete NAME",
+		Aliases: []string{"rm", "remove"},
+		Short:   "Delete a Parameter Set",
+		Long:    `Delete a named set of parameters.`,
+		Example: `  porter parameters delete dev`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.DeleteParameter(opts)
+		},
+	}
+
+	return cmd
+}
+
+func buildParametersEditCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.ParameterEditOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "edit NAME",
+		Short: "Edit a Parameter Set",
+		Long: `Edit a named set of parameters.
+
+The parameter set is opened in the editor defined by the EDITOR environment
+variable, and is saved when the editor is closed.`,
+		Example: `  porter parameters edit dev
+  EDITOR="code --wait" porter parameters edit dev`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.EditParameter(opts)
+		},
+	}
+
+	return cmd
+}
+
+func buildParametersListCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.ListOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List parameter sets",
+		Long:    `List named sets of parameters defined by the user.`,
+		Example: `  porter parameters list [-o table|json|yaml]`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.ParseFormat()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ListParameters(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.RawFormat, "output", "o", "table",
+		"Specify an output format.  Allowed values: table, json, yaml")
+
+	return cmd
+}
+
+func buildParametersShowCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.ParameterShowOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "show NAME",
+		Short:   "Show a Parameter Set",
+		Long:    `Show a particular parameter set, including all named parameters and their corresponding mappings.`,
+		Example: `  porter parameters show NAME [-o table|json|yaml]`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ShowParameter(opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.RawFormat, "output", "o", "table",
+		"Specify an output format.  Allowed values: table, json, yaml")
+
+	return cmd
+}
@@ -206,15 +206,22 @@ func (g *DockerfileGenerator) getMixinBuildInput(m string) mixin.BuildInput {
 		}
 	}
 
-	filterSteps := func(action manifest.Action, steps manifest.Steps) {
-		mixinSteps := manifest.Steps{}
+	var appendMixinSteps func(mixinSteps manifest.Steps, steps manifest.Steps) manifest.Steps
+	appendMixinSteps = func(mixinSteps manifest.Steps, steps manifest.Steps) manifest.Steps {
 		for _, step := range steps {
+			if step.IsParallel() {
+				mixinSteps = appendMixinSteps(mixinSteps, step.Parallel)
+				continue
+			}
 			if step.GetMixinName() != m {
 				continue
 			}
-			mixinSteps = append(mixinSteps, step)
+			mixinSteps = append(mixinSteps, step.GetMixinStep())
 		}
-		input.Actions[string(action)] = mixinSteps
+		return mixinSteps
+	}
+	filterSteps := func(action manifest.Action, steps manifest.Steps) {
+		input.Actions[string(action)] = appendMixinSteps(manifest.Steps{}, steps)
 	}
 	filterSteps(manifest.ActionInstall, g.Manifest.Install)
 	filterSteps(manifest.ActionUpgrade, g.Manifest.Upgrade)
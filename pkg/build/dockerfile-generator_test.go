@@ -246,6 +246,33 @@ func TestDockerFileGenerator_getMixinBuildInput(t *testing.T) {
 	assert.Equal(t, map[interface{}]interface{}{"extensions": []interface{}{"iot"}}, input.Config, "az mixin should have config")
 }
 
+func TestDockerFileGenerator_getMixinBuildInput_ParallelSteps(t *testing.T) {
+	c := config.NewTestConfig(t)
+	tmpl := templates.NewTemplates()
+	c.TestContext.AddTestFile("testdata/multiple-mixins.yaml", config.Name)
+
+	m, err := manifest.LoadManifestFrom(c.Context, config.Name)
+	require.NoError(t, err, "could not load manifest")
+
+	// Move the install steps into a parallel step group
+	group := &manifest.Step{If: "{{ bundle.parameters.enabled }}", Parallel: m.Install}
+	m.Install = manifest.Steps{group}
+
+	mp := &mixin.TestMixinProvider{}
+	g := NewDockerfileGenerator(c.Config, m, tmpl, mp)
+
+	input := g.getMixinBuildInput("exec")
+
+	require.Contains(t, input.Actions, "install")
+	installSteps, ok := input.Actions["install"].(manifest.Steps)
+	require.True(t, ok, "expected the install steps to be manifest.Steps but got %T", input.Actions["install"])
+	assert.Len(t, installSteps, 2, "expected the exec install steps in the parallel step group")
+	for _, step := range installSteps {
+		assert.Equal(t, "exec", step.GetMixinName())
+		assert.Empty(t, step.If, "porter step fields should not be passed to the mixin")
+	}
+}
+
 func TestPorter_replacePorterMixinTokenWithBuildInstructions(t *testing.T) {
 	c := config.NewTestConfig(t)
 	tmpl := templates.NewTemplates()
@@ -11,4 +11,22 @@ type ClaimProvider interface {
 	Read(name string) (claim.Claim, error)
 	ReadAll() ([]claim.Claim, error)
 	Delete(name string) error
+
+	// SaveRun adds a run to the history of its installation.
+	SaveRun(run Run) error
+
+	// ReadHistory returns the runs of an installation, from oldest to newest.
+	ReadHistory(installation string) ([]Run, error)
+
+	// SaveLogs saves the logs of a run of an installation.
+	SaveLogs(installation string, runID string, logs string) error
+
+	// ReadLogs returns the logs of a run of an installation.
+	ReadLogs(installation string, runID string) (string, error)
+
+	// SaveSnapshot saves the bundle and parameters used by a run of an installation.
+	SaveSnapshot(installation string, runID string, snapshot Snapshot) error
+
+	// ReadSnapshot returns the bundle and parameters used by a run of an installation.
+	ReadSnapshot(installation string, runID string) (Snapshot, error)
 }
@@ -13,11 +13,13 @@ var _ ClaimProvider = &ClaimStorage{}
 type ClaimStorage struct {
 	*config.Config
 	claim.Store
+	HistoryStore
 }
 
 func NewClaimStorage(c *config.Config, storagePlugin *pluginstore.Store) *ClaimStorage {
 	return &ClaimStorage{
-		Config: c,
-		Store:  claim.NewClaimStore(storagePlugin),
+		Config:       c,
+		Store:        claim.NewClaimStore(storagePlugin),
+		HistoryStore: NewHistoryStore(storagePlugin),
 	}
 }
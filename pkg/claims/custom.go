@@ -0,0 +1,72 @@
+package claims
+
+import (
+	"encoding/json"
+
+	"get.porter.sh/porter/pkg/parameters"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/pkg/errors"
+)
+
+// readCustomValue reads the value of a key in the custom data on a claim into v.
+// Returns false when the key is not set.
+func readCustomValue(c claim.Claim, key string, v interface{}) (bool, error) {
+	custom, err := readCustomData(c)
+	if err != nil {
+		return false, err
+	}
+
+	data, ok := custom[key]
+	if !ok {
+		return false, nil
+	}
+
+	// The custom data is untyped after it is loaded from storage, so round trip it through json
+	dataB, err := json.Marshal(parameters.ToJSONCompatible(data))
+	if err != nil {
+		return false, errors.Wrapf(err, "could not marshal the %s custom data on claim %s", key, c.Name)
+	}
+
+	err = json.Unmarshal(dataB, v)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not unmarshal the %s custom data on claim %s", key, c.Name)
+	}
+
+	return true, nil
+}
+
+// setCustomValue sets the value of a key in the custom data on a claim,
+// preserving any other custom data. The key is removed when the value is nil.
+func setCustomValue(c *claim.Claim, key string, value interface{}) error {
+	custom, err := readCustomData(*c)
+	if err != nil {
+		return err
+	}
+
+	if custom == nil {
+		custom = make(map[string]interface{}, 1)
+	}
+	if value == nil {
+		delete(custom, key)
+	} else {
+		custom[key] = value
+	}
+
+	c.Custom = custom
+	return nil
+}
+
+// readCustomData returns the custom data on a claim as a map, so that Porter's
+// data can be stored alongside any other custom data.
+func readCustomData(c claim.Claim) (map[string]interface{}, error) {
+	if c.Custom == nil {
+		return nil, nil
+	}
+
+	custom, ok := parameters.ToJSONCompatible(c.Custom).(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("unsupported custom data on claim %s, expected a map but got %T", c.Name, c.Custom)
+	}
+
+	return custom, nil
+}
@@ -0,0 +1,92 @@
+package claims
+
+import (
+	"github.com/cnabio/cnab-go/claim"
+)
+
+// DependentsKey is the key in a claim's custom data where Porter tracks the
+// installations that use the claim's installation as a shared dependency.
+const DependentsKey = "sh.porter.dependents"
+
+// SharedDependenciesKey is the key in a claim's custom data where Porter tracks the
+// shared installations that the claim's installation uses for its dependencies.
+const SharedDependenciesKey = "sh.porter.sharedDependencies"
+
+// Dependent is an installation that references another installation as one of
+// its dependencies, instead of installing its own copy of the dependency.
+type Dependent struct {
+	// Installation is the name of the parent installation.
+	Installation string `json:"installation"`
+
+	// Alias is the name of the dependency in the parent bundle.
+	Alias string `json:"alias"`
+}
+
+// ReadDependents returns the installations that reference the claim as a shared dependency.
+func ReadDependents(c claim.Claim) ([]Dependent, error) {
+	var dependents []Dependent
+	_, err := readCustomValue(c, DependentsKey, &dependents)
+	return dependents, err
+}
+
+// AddDependent records that an installation references the claim as a shared dependency.
+// Returns false when the reference was already recorded.
+func AddDependent(c *claim.Claim, dependent Dependent) (bool, error) {
+	dependents, err := ReadDependents(*c)
+	if err != nil {
+		return false, err
+	}
+
+	for _, d := range dependents {
+		if d == dependent {
+			return false, nil
+		}
+	}
+
+	return true, setDependents(c, append(dependents, dependent))
+}
+
+// RemoveDependent removes the reference from an installation to the claim.
+// Returns false when the reference was not recorded.
+func RemoveDependent(c *claim.Claim, dependent Dependent) (bool, error) {
+	dependents, err := ReadDependents(*c)
+	if err != nil {
+		return false, err
+	}
+
+	remaining := make([]Dependent, 0, len(dependents))
+	for _, d := range dependents {
+		if d != dependent {
+			remaining = append(remaining, d)
+		}
+	}
+
+	if len(remaining) == len(dependents) {
+		return false, nil
+	}
+	return true, setDependents(c, remaining)
+}
+
+func setDependents(c *claim.Claim, dependents []Dependent) error {
+	if len(dependents) == 0 {
+		return setCustomValue(c, DependentsKey, nil)
+	}
+	return setCustomValue(c, DependentsKey, dependents)
+}
+
+// ReadSharedDependencies returns the shared installations that the claim uses for its
+// dependencies, keyed by the dependency alias.
+func ReadSharedDependencies(c claim.Claim) (map[string]string, error) {
+	var bindings map[string]string
+	_, err := readCustomValue(c, SharedDependenciesKey, &bindings)
+	return bindings, err
+}
+
+// SetSharedDependencies records the shared installations that the claim uses for its
+// dependencies, keyed by the dependency alias.
+func SetSharedDependencies(c *claim.Claim, bindings map[string]string) error {
+	if len(bindings) == 0 {
+		return setCustomValue(c, SharedDependenciesKey, nil)
+	}
+	return setCustomValue(c, SharedDependenciesKey, bindings)
+}
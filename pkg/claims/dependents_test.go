@@ -0,0 +1,74 @@
+package claims
+
+import (
+	"testing"
+
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependents(t *testing.T) {
+	store := NewTestClaimProvider()
+
+	c, err := claim.New("mysql")
+	require.NoError(t, err)
+	c.Custom = map[string]interface{}{"other": "data"}
+
+	app := Dependent{Installation: "app", Alias: "db"}
+	added, err := AddDependent(c, app)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = AddDependent(c, app)
+	require.NoError(t, err)
+	assert.False(t, added, "the same reference should only be recorded once")
+
+	// Round trip through storage, so that the custom data is untyped
+	require.NoError(t, store.Save(*c))
+	saved, err := store.Read("mysql")
+	require.NoError(t, err)
+
+	dependents, err := ReadDependents(saved)
+	require.NoError(t, err)
+	assert.Equal(t, []Dependent{app}, dependents)
+
+	removed, err := RemoveDependent(&saved, app)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	assert.Equal(t, map[string]interface{}{"other": "data"}, saved.Custom, "other custom data should be preserved")
+
+	removed, err = RemoveDependent(&saved, app)
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
+
+func TestReadDependents_UnsupportedCustomData(t *testing.T) {
+	c := claim.Claim{Name: "mysql", Custom: "stuff"}
+	_, err := ReadDependents(c)
+	require.EqualError(t, err, "unsupported custom data on claim mysql, expected a map but got string")
+}
+
+func TestSharedDependencies(t *testing.T) {
+	store := NewTestClaimProvider()
+
+	c, err := claim.New("wordpress")
+	require.NoError(t, err)
+	c.Custom = map[string]interface{}{"other": "data"}
+
+	err = SetSharedDependencies(c, map[string]string{"db": "shared-mysql"})
+	require.NoError(t, err)
+
+	// Round trip through storage, so that the custom data is untyped
+	require.NoError(t, store.Save(*c))
+	saved, err := store.Read("wordpress")
+	require.NoError(t, err)
+
+	bindings, err := ReadSharedDependencies(saved)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"db": "shared-mysql"}, bindings)
+
+	err = SetSharedDependencies(&saved, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"other": "data"}, saved.Custom, "other custom data should be preserved")
+}
@@ -9,11 +9,13 @@ var _ ClaimProvider = &TestClaimProvider{}
 
 type TestClaimProvider struct {
 	claim.Store
+	HistoryStore
 }
 
 func NewTestClaimProvider() TestClaimProvider {
 	crud := inmemory.NewStore()
 	return TestClaimProvider{
-		Store: claim.NewClaimStore(crud),
+		Store:        claim.NewClaimStore(crud),
+		HistoryStore: NewHistoryStore(crud),
 	}
 }
@@ -0,0 +1,253 @@
+package claims
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"get.porter.sh/porter/pkg"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/cnabio/cnab-go/utils/crud"
+	"github.com/pkg/errors"
+)
+
+// HistoryItemType is the type of item stored for the runs of an installation.
+const HistoryItemType = "history"
+
+// LogsItemType is the type of item stored for the logs of a run.
+const LogsItemType = "logs"
+
+// SnapshotsItemType is the type of item stored for the snapshots of successful runs.
+const SnapshotsItemType = "snapshots"
+
+// ErrLogsNotFound represents logs that were not recorded for a run.
+var ErrLogsNotFound = errors.New("Logs do not exist")
+
+// ErrSnapshotNotFound represents a snapshot that was not recorded for a run.
+var ErrSnapshotNotFound = errors.New("Snapshot does not exist")
+
+// RedactedValue replaces the value of sensitive parameters and outputs in the history.
+const RedactedValue = "******"
+
+// Run is the record of an action that was executed against an installation.
+// Unlike the claim, which only has the result of the last action, a run is
+// kept for every action.
+type Run struct {
+	// ID of the run, the revision of the claim that the run created.
+	ID string `json:"id"`
+
+	// Installation is the name of the installation.
+	Installation string `json:"installation"`
+
+	// Action that was executed.
+	Action string `json:"action"`
+
+	// Timestamp is when the run completed.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Status is the result of the run, such as success or failure.
+	Status string `json:"status"`
+
+	// Message explains the status of the run, such as why it failed.
+	Message string `json:"message,omitempty"`
+
+	// BundleName is the name of the bundle.
+	BundleName string `json:"bundleName"`
+
+	// BundleVersion is the version of the bundle.
+	BundleVersion string `json:"bundleVersion"`
+
+	// Parameters used by the run, the values of sensitive parameters are redacted.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// Outputs generated by the run, the values of sensitive outputs are redacted.
+	Outputs map[string]interface{} `json:"outputs,omitempty"`
+
+	// PorterVersion is the version of Porter that executed the run.
+	PorterVersion string `json:"porterVersion"`
+
+	// User that executed the run.
+	User string `json:"user,omitempty"`
+
+	// RollbackTo is the id of the run that the installation was rolled back to,
+	// when the run was a rollback.
+	RollbackTo string `json:"rollbackTo,omitempty"`
+}
+
+// Snapshot is the bundle and the parameters used by a successful run, so that the
+// installation can be rolled back to the state of the run. Unlike the run, the values
+// of sensitive parameters are not redacted.
+type Snapshot struct {
+	// Bundle that was executed.
+	Bundle *bundle.Bundle `json:"bundle"`
+
+	// Parameters used by the run.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// NewRun records the last action executed against the claim.
+func NewRun(c claim.Claim) Run {
+	run := Run{
+		ID:            c.Revision,
+		Installation:  c.Name,
+		Action:        c.Result.Action,
+		Timestamp:     c.Modified,
+		Status:        c.Result.Status,
+		Message:       c.Result.Message,
+		PorterVersion: pkg.Version,
+	}
+
+	var definitions map[string]bool
+	if c.Bundle != nil {
+		run.BundleName = c.Bundle.Name
+		run.BundleVersion = c.Bundle.Version
+		definitions = sensitiveDefinitions(c.Bundle)
+	}
+
+	run.Parameters = make(map[string]interface{}, len(c.Parameters))
+	for name, value := range c.Parameters {
+		if c.Bundle != nil && definitions[c.Bundle.Parameters[name].Definition] {
+			value = RedactedValue
+		}
+		run.Parameters[name] = value
+	}
+
+	run.Outputs = make(map[string]interface{}, len(c.Outputs))
+	for name, value := range c.Outputs {
+		if c.Bundle != nil && definitions[c.Bundle.Outputs[name].Definition] {
+			value = RedactedValue
+		}
+		run.Outputs[name] = value
+	}
+
+	return run
+}
+
+// sensitiveDefinitions returns the names of the definitions of the bundle that are sensitive.
+func sensitiveDefinitions(bun *bundle.Bundle) map[string]bool {
+	sensitive := make(map[string]bool)
+	for name, def := range bun.Definitions {
+		if def.WriteOnly != nil && *def.WriteOnly {
+			sensitive[name] = true
+		}
+	}
+	return sensitive
+}
+
+// HistoryStore is a persistent store for the runs of each installation.
+// Runs are only ever added, so that the history of an installation is kept
+// after the installation is upgraded or uninstalled.
+type HistoryStore struct {
+	backingStore *crud.BackingStore
+}
+
+// NewHistoryStore creates a persistent store for the history of installations using
+// the specified backing key-blob store.
+func NewHistoryStore(store crud.Store) HistoryStore {
+	return HistoryStore{
+		backingStore: crud.NewBackingStore(store),
+	}
+}
+
+// SaveRun adds a run to the history of its installation.
+func (s HistoryStore) SaveRun(run Run) error {
+	if run.Installation == "" || run.ID == "" {
+		return errors.New("the run must have an installation and an id")
+	}
+
+	bytes, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal run %s of installation %s", run.ID, run.Installation)
+	}
+	return s.backingStore.Save(HistoryItemType, historyKey(run.Installation, run.ID), bytes)
+}
+
+// ReadHistory returns the runs of an installation, from oldest to newest.
+func (s HistoryStore) ReadHistory(installation string) ([]Run, error) {
+	keys, err := s.backingStore.List(HistoryItemType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list the history of installation %s", installation)
+	}
+
+	prefix := historyKey(installation, "")
+	runs := make([]Run, 0)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		bytes, err := s.backingStore.Read(HistoryItemType, key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read run %s of installation %s", key, installation)
+		}
+
+		var run Run
+		err = json.Unmarshal(bytes, &run)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal run %s of installation %s", key, installation)
+		}
+
+		// The prefix also matches installations that start with the name, e.g. mysql.test
+		if run.Installation == installation {
+			runs = append(runs, run)
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		if runs[i].Timestamp.Equal(runs[j].Timestamp) {
+			// The ids are ULIDs, which sort in the order they were created
+			return runs[i].ID < runs[j].ID
+		}
+		return runs[i].Timestamp.Before(runs[j].Timestamp)
+	})
+	return runs, nil
+}
+
+// SaveLogs saves the logs of a run of an installation.
+func (s HistoryStore) SaveLogs(installation string, runID string, logs string) error {
+	return s.backingStore.Save(LogsItemType, historyKey(installation, runID), []byte(logs))
+}
+
+// ReadLogs returns the logs of a run of an installation.
+func (s HistoryStore) ReadLogs(installation string, runID string) (string, error) {
+	bytes, err := s.backingStore.Read(LogsItemType, historyKey(installation, runID))
+	if err != nil {
+		if err == crud.ErrRecordDoesNotExist {
+			return "", ErrLogsNotFound
+		}
+		return "", errors.Wrapf(err, "could not read the logs of run %s of installation %s", runID, installation)
+	}
+	return string(bytes), nil
+}
+
+// SaveSnapshot saves the snapshot of a run of an installation.
+func (s HistoryStore) SaveSnapshot(installation string, runID string, snapshot Snapshot) error {
+	bytes, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal the snapshot of run %s of installation %s", runID, installation)
+	}
+	return s.backingStore.Save(SnapshotsItemType, historyKey(installation, runID), bytes)
+}
+
+// ReadSnapshot returns the snapshot of a run of an installation.
+func (s HistoryStore) ReadSnapshot(installation string, runID string) (Snapshot, error) {
+	bytes, err := s.backingStore.Read(SnapshotsItemType, historyKey(installation, runID))
+	if err != nil {
+		if err == crud.ErrRecordDoesNotExist {
+			return Snapshot{}, ErrSnapshotNotFound
+		}
+		return Snapshot{}, errors.Wrapf(err, "could not read the snapshot of run %s of installation %s", runID, installation)
+	}
+
+	var snapshot Snapshot
+	err = json.Unmarshal(bytes, &snapshot)
+	return snapshot, errors.Wrapf(err, "could not unmarshal the snapshot of run %s of installation %s", runID, installation)
+}
+
+// historyKey is the key of a run, prefixed with the installation so that the runs of an
+// installation are grouped together.
+func historyKey(installation string, id string) string {
+	return installation + "." + id
+}
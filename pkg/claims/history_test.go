@@ -0,0 +1,93 @@
+package claims
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRun(t *testing.T) {
+	sensitive := true
+	c, err := claim.New("mybuns")
+	require.NoError(t, err)
+	c.Bundle = &bundle.Bundle{
+		Name:    "mybuns",
+		Version: "0.1.0",
+		Definitions: definition.Definitions{
+			"password": &definition.Schema{Type: "string", WriteOnly: &sensitive},
+			"string":   &definition.Schema{Type: "string"},
+		},
+		Parameters: map[string]bundle.Parameter{
+			"password": {Definition: "password"},
+			"region":   {Definition: "string"},
+		},
+		Outputs: map[string]bundle.Output{
+			"connstr": {Definition: "password"},
+			"host":    {Definition: "string"},
+		},
+	}
+	c.Parameters = map[string]interface{}{"password": "s3cr3t", "region": "eastus"}
+	c.Outputs = map[string]interface{}{"connstr": "mysql://root:s3cr3t@db", "host": "db"}
+	c.Update(claim.ActionInstall, claim.StatusFailure)
+	c.Result.Message = "oops"
+
+	run := NewRun(*c)
+
+	assert.Equal(t, c.Revision, run.ID)
+	assert.Equal(t, "mybuns", run.Installation)
+	assert.Equal(t, claim.ActionInstall, run.Action)
+	assert.Equal(t, claim.StatusFailure, run.Status)
+	assert.Equal(t, "oops", run.Message)
+	assert.Equal(t, c.Modified, run.Timestamp)
+	assert.Equal(t, "mybuns", run.BundleName)
+	assert.Equal(t, "0.1.0", run.BundleVersion)
+	assert.Equal(t, map[string]interface{}{"password": RedactedValue, "region": "eastus"}, run.Parameters, "sensitive parameters should be redacted")
+	assert.Equal(t, map[string]interface{}{"connstr": RedactedValue, "host": "db"}, run.Outputs, "sensitive outputs should be redacted")
+}
+
+func TestHistoryStore(t *testing.T) {
+	cp := NewTestClaimProvider()
+
+	now := time.Now()
+	runs := []Run{
+		{ID: "01E2ZZ1", Installation: "mysql", Action: "upgrade", Timestamp: now.Add(time.Minute)},
+		{ID: "01E2ZZ0", Installation: "mysql", Action: "install", Timestamp: now},
+		{ID: "01E2ZZ2", Installation: "mysql.test", Action: "install", Timestamp: now},
+		{ID: "01E2ZZ3", Installation: "wordpress", Action: "install", Timestamp: now},
+	}
+	for _, run := range runs {
+		require.NoError(t, cp.SaveRun(run))
+	}
+
+	history, err := cp.ReadHistory("mysql")
+	require.NoError(t, err)
+	require.Len(t, history, 2, "only the runs of the installation should be returned")
+	assert.Equal(t, "install", history[0].Action, "the runs should be sorted from oldest to newest")
+	assert.Equal(t, "upgrade", history[1].Action, "the runs should be sorted from oldest to newest")
+
+	history, err = cp.ReadHistory("missing")
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	err = cp.SaveRun(Run{Installation: "mysql"})
+	assert.EqualError(t, err, "the run must have an installation and an id")
+}
+
+func TestHistoryStore_Logs(t *testing.T) {
+	cp := NewTestClaimProvider()
+
+	err := cp.SaveLogs("mysql", "01E2ZZ0", "installing mysql...")
+	require.NoError(t, err)
+
+	logs, err := cp.ReadLogs("mysql", "01E2ZZ0")
+	require.NoError(t, err)
+	assert.Equal(t, "installing mysql...", logs)
+
+	_, err = cp.ReadLogs("mysql", "01E2ZZ1")
+	assert.Equal(t, ErrLogsNotFound, err)
+}
@@ -0,0 +1,97 @@
+package claims
+
+import (
+	"encoding/json"
+
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/pkg/errors"
+)
+
+const (
+	// StepsKey is the key in a claim's custom data where Porter records the
+	// steps that completed during the last failed action.
+	StepsKey = "sh.porter.steps"
+
+	// StepsOutput is the name of the bundle output where the Porter runtime
+	// records the steps that completed during an action.
+	StepsOutput = "porter-steps"
+)
+
+// ActionProgress is the progress of the Porter runtime through the steps of an action.
+type ActionProgress struct {
+	// Action is the name of the action.
+	Action string `json:"action"`
+
+	// Steps are the steps of the action that completed, in the order that they completed.
+	Steps []CompletedStep `json:"steps,omitempty"`
+}
+
+// CompletedStep is a step of an action that completed successfully.
+type CompletedStep struct {
+	// ID identifies the step by its position in the action.
+	ID string `json:"id"`
+
+	// Description is the description of the step.
+	Description string `json:"description"`
+
+	// Outputs are the names of the outputs generated by the step. Their values are not
+	// recorded because they may be sensitive.
+	Outputs []string `json:"outputs,omitempty"`
+}
+
+// GetCompletedStep returns the completed step at the specified position in the action.
+// The step is only returned when its description also matches, so that steps are not
+// mixed up when the bundle has changed.
+func (p ActionProgress) GetCompletedStep(id string, description string) (CompletedStep, bool) {
+	for _, step := range p.Steps {
+		if step.ID == id && step.Description == description {
+			return step, true
+		}
+	}
+	return CompletedStep{}, false
+}
+
+// ParseActionProgress parses the progress recorded by the Porter runtime in the StepsOutput.
+func ParseActionProgress(data string) (ActionProgress, error) {
+	var progress ActionProgress
+	err := json.Unmarshal([]byte(data), &progress)
+	return progress, errors.Wrap(err, "could not parse the progress of the action")
+}
+
+// ReadActionProgress returns the progress recorded on the claim by the last failed action.
+// Returns nil when no progress was recorded.
+func ReadActionProgress(c claim.Claim) (*ActionProgress, error) {
+	var progress ActionProgress
+	ok, err := readCustomValue(c, StepsKey, &progress)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// RecordActionProgress moves the progress of the action recorded by the Porter runtime from
+// the claim's outputs into its custom data. The progress is only kept when the action failed,
+// so that it can be resumed.
+func RecordActionProgress(c *claim.Claim) error {
+	data, ok := c.Outputs[StepsOutput]
+	if !ok {
+		return setCustomValue(c, StepsKey, nil)
+	}
+	delete(c.Outputs, StepsOutput)
+
+	if c.Result.Status != claim.StatusFailure {
+		return setCustomValue(c, StepsKey, nil)
+	}
+
+	dataS, ok := data.(string)
+	if !ok {
+		return errors.Errorf("invalid %s output on claim %s, expected a string but got %T", StepsOutput, c.Name, data)
+	}
+
+	progress, err := ParseActionProgress(dataS)
+	if err != nil {
+		return err
+	}
+
+	return setCustomValue(c, StepsKey, progress)
+}
@@ -0,0 +1,66 @@
+package claims
+
+import (
+	"testing"
+
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProgress = `{"action":"install","steps":[{"id":"1","description":"Install mysql","outputs":["host"]}]}`
+
+func TestRecordActionProgress_Failed(t *testing.T) {
+	store := NewTestClaimProvider()
+
+	c, err := claim.New("mysql")
+	require.NoError(t, err)
+	c.Result.Status = claim.StatusFailure
+	c.Outputs = map[string]interface{}{
+		StepsOutput: testProgress,
+		"host":      "localhost",
+	}
+
+	require.NoError(t, RecordActionProgress(c))
+	assert.Equal(t, map[string]interface{}{"host": "localhost"}, c.Outputs, "the progress should not be kept as an output")
+
+	// Round trip through storage, so that the custom data is untyped
+	require.NoError(t, store.Save(*c))
+	saved, err := store.Read("mysql")
+	require.NoError(t, err)
+
+	progress, err := ReadActionProgress(saved)
+	require.NoError(t, err)
+	require.NotNil(t, progress)
+	assert.Equal(t, "install", progress.Action)
+
+	step, ok := progress.GetCompletedStep("1", "Install mysql")
+	require.True(t, ok)
+	assert.Equal(t, []string{"host"}, step.Outputs)
+
+	_, ok = progress.GetCompletedStep("1", "Install postgres")
+	assert.False(t, ok, "a step should not match when its description has changed")
+}
+
+func TestRecordActionProgress_Succeeded(t *testing.T) {
+	c, err := claim.New("mysql")
+	require.NoError(t, err)
+	c.Result.Status = claim.StatusSuccess
+	c.Custom = map[string]interface{}{StepsKey: map[string]interface{}{"action": "install"}}
+	c.Outputs = map[string]interface{}{StepsOutput: testProgress}
+
+	require.NoError(t, RecordActionProgress(c))
+	assert.Empty(t, c.Outputs)
+
+	progress, err := ReadActionProgress(*c)
+	require.NoError(t, err)
+	assert.Nil(t, progress, "the progress of an earlier failed run should be cleared")
+}
+
+func TestRecordActionProgress_InvalidOutput(t *testing.T) {
+	c := &claim.Claim{Name: "mysql", Outputs: map[string]interface{}{StepsOutput: 1}}
+	c.Result.Status = claim.StatusFailure
+
+	err := RecordActionProgress(c)
+	require.EqualError(t, err, "invalid porter-steps output on claim mysql, expected a string but got int")
+}
@@ -5,6 +5,7 @@ import (
 	"path"
 	"strings"
 
+	"get.porter.sh/porter/pkg/claims"
 	"get.porter.sh/porter/pkg/cnab/extensions"
 	"get.porter.sh/porter/pkg/config"
 	"get.porter.sh/porter/pkg/context"
@@ -22,6 +23,9 @@ type ManifestConverter struct {
 	Manifest     *manifest.Manifest
 	ImageDigests map[string]string
 	Mixins       []mixin.Metadata
+
+	// DependencyLocks are the resolved versions of the bundle's dependencies, from porter.lock.
+	DependencyLocks extensions.DependencyLocks
 }
 
 func NewManifestConverter(cxt *context.Context, manifest *manifest.Manifest, imageDigests map[string]string, mixins []mixin.Metadata) *ManifestConverter {
@@ -62,6 +66,15 @@ func (c *ManifestConverter) ToBundle() *bundle.Bundle {
 	if len(c.Manifest.Dependencies) > 0 {
 		b.RequiredExtensions = []string{extensions.DependenciesKey}
 	}
+	if len(c.DependencyLocks) > 0 {
+		b.Custom[extensions.DependencyLocksKey] = c.DependencyLocks
+	}
+	if params := c.generateDependencyParameters(); len(params) > 0 {
+		b.Custom[extensions.DependencyParametersKey] = params
+	}
+	if sources := c.generateParameterSources(); len(sources) > 0 {
+		b.Custom[extensions.ParameterSourcesKey] = sources
+	}
 
 	return b
 }
@@ -172,30 +185,31 @@ func (c *ManifestConverter) generateBundleParameters(defs *definition.Definition
 }
 
 func (c *ManifestConverter) generateBundleOutputs(defs *definition.Definitions) map[string]bundle.Output {
-	var outputs map[string]bundle.Output
-
-	if len(c.Manifest.Outputs) > 0 {
-		outputs = make(map[string]bundle.Output, len(c.Manifest.Outputs))
-
-		for _, output := range c.Manifest.Outputs {
-			o := bundle.Output{
-				Definition:  output.Name,
-				Description: output.Description,
-				ApplyTo:     output.ApplyTo,
-				// must be a standard Unix path as this will be inside of the container
-				// (only linux containers supported currently)
-				Path: path.Join(config.BundleOutputsDir, output.Name),
-			}
-
-			if output.Sensitive {
-				output.Schema.WriteOnly = toBool(true)
-			}
+	// Copy the outputs so that the manifest isn't modified
+	manifestOutputs := make([]manifest.OutputDefinition, 0, len(c.Manifest.Outputs)+1)
+	manifestOutputs = append(manifestOutputs, c.Manifest.Outputs...)
+	manifestOutputs = append(manifestOutputs, c.buildDefaultPorterOutputs()...)
+	outputs := make(map[string]bundle.Output, len(manifestOutputs))
+
+	for _, output := range manifestOutputs {
+		o := bundle.Output{
+			Definition:  output.Name,
+			Description: output.Description,
+			ApplyTo:     output.ApplyTo,
+			// must be a standard Unix path as this will be inside of the container
+			// (only linux containers supported currently)
+			Path: path.Join(config.BundleOutputsDir, output.Name),
+		}
 
-			defName := c.addDefinition(output.Name, "output", output.Schema, defs)
-			o.Definition = defName
-			outputs[output.Name] = o
+		if output.Sensitive {
+			output.Schema.WriteOnly = toBool(true)
 		}
+
+		defName := c.addDefinition(output.Name, "output", output.Schema, defs)
+		o.Definition = defName
+		outputs[output.Name] = o
 	}
+
 	return outputs
 }
 
@@ -229,6 +243,21 @@ func (c *ManifestConverter) buildDefaultPorterParameters() []manifest.ParameterD
 	}
 }
 
+func (c *ManifestConverter) buildDefaultPorterOutputs() []manifest.OutputDefinition {
+	return []manifest.OutputDefinition{
+		{
+			Name: claims.StepsOutput,
+			Schema: definition.Schema{
+				Description: "The steps completed by Porter when executing the bundle, used to resume a failed action",
+				Type:        "string",
+			},
+			// Only install and upgrade can be resumed
+			ApplyTo:   []string{string(manifest.ActionInstall), string(manifest.ActionUpgrade)},
+			Sensitive: true,
+		},
+	}
+}
+
 func (c *ManifestConverter) generateBundleCredentials() map[string]bundle.Credential {
 	params := map[string]bundle.Credential{}
 	for _, cred := range c.Manifest.Credentials {
@@ -306,6 +335,39 @@ func (c *ManifestConverter) generateDependencies() *extensions.Dependencies {
 	return deps
 }
 
+// generateDependencyParameters records the parameter values that the bundle passes to its
+// dependencies, so that they are available when the bundle is used from a tag.
+func (c *ManifestConverter) generateDependencyParameters() extensions.DependencyParameters {
+	params := make(extensions.DependencyParameters)
+	for name, dep := range c.Manifest.Dependencies {
+		if len(dep.Parameters) == 0 {
+			continue
+		}
+
+		params[name] = make(map[string]string, len(dep.Parameters))
+		for param, value := range dep.Parameters {
+			params[name][param] = value
+		}
+	}
+	return params
+}
+
+// generateParameterSources records the outputs that are used as the default values of parameters.
+func (c *ManifestConverter) generateParameterSources() extensions.ParameterSources {
+	sources := make(extensions.ParameterSources)
+	for _, param := range c.Manifest.Parameters {
+		if param.Source == nil {
+			continue
+		}
+
+		sources[param.Name] = extensions.ParameterSource{
+			Installation: param.Source.Installation,
+			Output:       param.Source.Output,
+		}
+	}
+	return sources
+}
+
 func toBool(value bool) *bool {
 	return &value
 }
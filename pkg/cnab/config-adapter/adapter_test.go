@@ -40,7 +40,17 @@ func TestManifestConverter_ToBundle(t *testing.T) {
 	assert.Contains(t, bun.Custom, config.CustomBundleKey, "Porter stamp was not populated")
 	assert.Contains(t, bun.Custom, extensions.DependenciesKey, "Dependencies was not populated")
 
-	assert.Nil(t, bun.Outputs, "expected outputs section not to exist in generated bundle")
+	// The manifest doesn't define outputs, so only the output where Porter records the completed steps is generated
+	wantOutputs := map[string]bundle.Output{
+		"porter-steps": {
+			Definition:  "porter-steps-output",
+			Description: "The steps completed by Porter when executing the bundle, used to resume a failed action",
+			ApplyTo:     []string{"install", "upgrade"},
+			Path:        "/cnab/app/outputs/porter-steps",
+		},
+	}
+	assert.Equal(t, wantOutputs, bun.Outputs, "expected only the porter-steps output in the generated bundle")
+	assert.Contains(t, bun.Definitions, "porter-steps-output", "porter-steps definition was not defined")
 }
 
 func TestManifestConverter_generateBundleParametersSchema(t *testing.T) {
@@ -331,11 +341,13 @@ func TestManifestConverter_generateBundleOutputs(t *testing.T) {
 		},
 	}
 
-	a.Manifest.Outputs = outputDefinitions
+	// Leave room in the slice to check that the porter outputs aren't added to the manifest
+	a.Manifest.Outputs = append(make([]manifest.OutputDefinition, 0, 4), outputDefinitions...)
 
 	defs := make(definition.Definitions, len(a.Manifest.Outputs))
 	outputs := a.generateBundleOutputs(&defs)
-	require.Len(t, defs, 3)
+	require.Len(t, defs, 4)
+	assert.Empty(t, a.Manifest.Outputs[:4][3].Name, "the manifest outputs should not be modified")
 
 	wantOutputDefinitions := map[string]bundle.Output{
 		"output1": {
@@ -357,6 +369,12 @@ func TestManifestConverter_generateBundleOutputs(t *testing.T) {
 			Description: "Description of kubeconfig",
 			Path:        "/cnab/app/outputs/kubeconfig",
 		},
+		"porter-steps": {
+			Definition:  "porter-steps-output",
+			Description: "The steps completed by Porter when executing the bundle, used to resume a failed action",
+			ApplyTo:     []string{"install", "upgrade"},
+			Path:        "/cnab/app/outputs/porter-steps",
+		},
 	}
 
 	require.Equal(t, wantOutputDefinitions, outputs)
@@ -376,6 +394,11 @@ func TestManifestConverter_generateBundleOutputs(t *testing.T) {
 			ContentEncoding: "base64",
 			Description:     "Description of kubeconfig",
 		},
+		"porter-steps-output": &definition.Schema{
+			Type:        "string",
+			Description: "The steps completed by Porter when executing the bundle, used to resume a failed action",
+			WriteOnly:   toBool(true),
+		},
 	}
 
 	require.Equal(t, wantDefinitions, defs)
@@ -434,6 +457,48 @@ func TestManifestConverter_generateDependencies(t *testing.T) {
 	}
 }
 
+func TestManifestConverter_generateDependencyParameters(t *testing.T) {
+	c := config.NewTestConfig(t)
+	c.TestContext.AddTestFile("testdata/porter-with-deps.yaml", config.Name)
+
+	m, err := manifest.LoadManifestFrom(c.Context, config.Name)
+	require.NoError(t, err, "could not load manifest")
+
+	a := NewManifestConverter(c.Context, m, nil, nil)
+
+	bun := a.ToBundle()
+	params, err := extensions.ReadDependencyParameters(bun)
+	require.NoError(t, err)
+
+	wantParams := extensions.DependencyParameters{
+		"mysql": {
+			"database-name": "wordpress",
+			"region":        "{{ bundle.parameters.region }}",
+		},
+	}
+	assert.Equal(t, wantParams, params, "only dependencies with parameters should be recorded")
+}
+
+func TestManifestConverter_generateParameterSources(t *testing.T) {
+	c := config.NewTestConfig(t)
+	c.TestContext.AddTestFile("testdata/porter-with-parameter-sources.yaml", config.Name)
+
+	m, err := manifest.LoadManifestFrom(c.Context, config.Name)
+	require.NoError(t, err, "could not load manifest")
+
+	a := NewManifestConverter(c.Context, m, nil, nil)
+
+	bun := a.ToBundle()
+	sources, err := extensions.ReadParameterSources(bun)
+	require.NoError(t, err)
+
+	wantSources := extensions.ParameterSources{
+		"admin-password": {Output: "admin-password"},
+		"mysql-host":     {Installation: "mysql", Output: "host"},
+	}
+	assert.Equal(t, wantSources, sources, "only parameters with a source should be recorded")
+}
+
 func TestManifestConverter_RequiredExtensions(t *testing.T) {
 	c := config.NewTestConfig(t)
 	c.TestContext.AddTestFile("testdata/porter-with-deps.yaml", config.Name)
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 
 	"get.porter.sh/porter/pkg"
 	"get.porter.sh/porter/pkg/config"
@@ -42,6 +43,25 @@ func (c *ManifestConverter) digestManifest() (string, error) {
 		return "", errors.Wrapf(err, "could not read manifest at %q", c.Manifest.ManifestPath)
 	}
 
+	// Include the files included by the manifest, so that changing them triggers a rebuild
+	for _, includePath := range c.Manifest.IncludedFiles {
+		includeData, err := c.FileSystem.ReadFile(includePath)
+		if err != nil {
+			return "", errors.Wrapf(err, "could not read included manifest file at %q", includePath)
+		}
+		data = append(data, includeData...)
+	}
+
+	// Include the dependency lock file, so that updating the locked versions triggers a rebuild
+	lockPath := filepath.Join(filepath.Dir(c.Manifest.ManifestPath), config.LockName)
+	if exists, _ := c.FileSystem.Exists(lockPath); exists {
+		lockData, err := c.FileSystem.ReadFile(lockPath)
+		if err != nil {
+			return "", errors.Wrapf(err, "could not read dependency lock file at %q", lockPath)
+		}
+		data = append(data, lockData...)
+	}
+
 	v := pkg.Version
 	data = append(data, v...)
 
@@ -53,3 +53,19 @@ func TestConfig_LoadStamp_Invalid(t *testing.T) {
 	assert.Contains(t, err.Error(), "could not unmarshal the porter stamp")
 	assert.Nil(t, stamp)
 }
+
+func TestConfig_ComputeManifestDigest_Includes(t *testing.T) {
+	c := config.NewTestConfig(t)
+	c.TestContext.AddTestDirectory("../../manifest/testdata/includes", ".")
+
+	m, err := manifest.LoadManifestFrom(c.Context, config.Name)
+	require.NoError(t, err, "could not load manifest")
+
+	a := NewManifestConverter(c.Context, m, nil, nil)
+	stamp := a.GenerateStamp()
+
+	// Changing a file included by an included file should change the digest
+	c.TestContext.AddTestFileContents([]byte("mixins: [exec, helm]"), "fragments/mixins.yaml")
+	newStamp := a.GenerateStamp()
+	assert.NotEqual(t, stamp.ManifestDigest, newStamp.ManifestDigest)
+}
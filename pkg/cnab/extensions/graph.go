@@ -0,0 +1,236 @@
+package extensions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/pkg/errors"
+)
+
+// BundleLoader retrieves the bundle definition for a resolved dependency tag,
+// for example REGISTRY/NAME:TAG, so that its own dependencies can be resolved.
+type BundleLoader func(tag string) (*bundle.Bundle, error)
+
+// DependencyNode is a bundle in a dependency graph.
+type DependencyNode struct {
+	// DependencyLock identifies the node. The alias is unique within the graph and
+	// is built from the aliases on the shortest path from the root bundle, for
+	// example "app-mysql" is the mysql dependency of the app dependency.
+	DependencyLock
+
+	// Bundle is the definition of the dependency.
+	Bundle *bundle.Bundle
+
+	// Requires maps the aliases used by this bundle's dependencies extension
+	// to the nodes that satisfy them.
+	Requires map[string]*DependencyNode
+}
+
+// DependencyGraph is the complete set of bundles required by a root bundle,
+// including the dependencies of its dependencies.
+type DependencyGraph struct {
+	// Root is the bundle whose dependencies were resolved.
+	Root *DependencyNode
+
+	// InstallOrder contains every dependency of the root bundle, not including the
+	// root itself, ordered so that each bundle comes after the bundles that it requires.
+	InstallOrder []*DependencyNode
+}
+
+// ResolveDependencyGraph resolves the dependencies of a bundle, following the
+// dependencies extension of each dependent bundle. Bundles that resolve to the
+// same tag are only included once, and are shared by every bundle that requires them.
+// When a dependency is locked, the locked tag is used and the digest of the
+// bundle must match the lock.
+func (s *DependencySolver) ResolveDependencyGraph(bun *bundle.Bundle, load BundleLoader) (*DependencyGraph, error) {
+	root := &DependencyNode{
+		DependencyLock: DependencyLock{Alias: bun.Name},
+		Bundle:         bun,
+	}
+
+	// Walk the graph breadth first, so that a shared bundle is named after
+	// the shortest path from the root
+	nodes := map[string]*DependencyNode{}
+	queue := []*DependencyNode{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		deps, err := ReadDependencies(node.Bundle)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading the dependencies of %s", node.Alias)
+		}
+		if deps == nil || len(deps.Requires) == 0 {
+			continue
+		}
+
+		node.Requires = make(map[string]*DependencyNode, len(deps.Requires))
+		for _, alias := range sortedAliases(deps) {
+			name := node.childAlias(root, alias)
+			tag, err := s.selectTag(name, deps.Requires[alias], nodes)
+			if err != nil {
+				return nil, err
+			}
+
+			dep, ok := nodes[tag]
+			if !ok {
+				dep, err = s.loadNode(name, tag, load)
+				if err != nil {
+					return nil, err
+				}
+				nodes[tag] = dep
+				queue = append(queue, dep)
+			}
+			node.Requires[alias] = dep
+		}
+	}
+
+	order, err := sortDependencies(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DependencyGraph{
+		Root:         root,
+		InstallOrder: order,
+	}, nil
+}
+
+// selectTag determines which tag to use for a dependency, preferring a locked tag,
+// then a tag that was already selected for another bundle in the graph,
+// before resolving the version from the registry.
+func (s *DependencySolver) selectTag(name string, dep Dependency, nodes map[string]*DependencyNode) (string, error) {
+	if lock, ok := s.Locks.Find(name); ok && satisfies(name, dep, lock.Tag) {
+		return lock.Tag, nil
+	}
+
+	tags := make([]string, 0, len(nodes))
+	for tag := range nodes {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		if satisfies(name, dep, tag) {
+			return tag, nil
+		}
+	}
+
+	return s.resolveTag(name, dep)
+}
+
+func (s *DependencySolver) loadNode(name string, tag string, load BundleLoader) (*DependencyNode, error) {
+	bun, err := load(tag)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading dependency %s", tag)
+	}
+
+	digest, err := DigestBundle(bun)
+	if err != nil {
+		return nil, err
+	}
+
+	if lock, ok := s.Locks.Find(name); ok && lock.Tag == tag && lock.Digest != "" && lock.Digest != digest {
+		return nil, errors.Errorf("the bundle for dependency %s (%s) has changed since it was locked, expected digest %s but found %s",
+			name, tag, lock.Digest, digest)
+	}
+
+	return &DependencyNode{
+		DependencyLock: DependencyLock{
+			Alias:  name,
+			Tag:    tag,
+			Digest: digest,
+		},
+		Bundle: bun,
+	}, nil
+}
+
+// childAlias builds the graph-wide alias for a dependency of this node.
+func (n *DependencyNode) childAlias(root *DependencyNode, alias string) string {
+	if n == root {
+		return alias
+	}
+	return fmt.Sprintf("%s-%s", n.Alias, alias)
+}
+
+// sortDependencies orders the dependencies of the root node topologically,
+// returning an error describing the path of any cycle that is found.
+func sortDependencies(root *DependencyNode) ([]*DependencyNode, error) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	var order []*DependencyNode
+	state := map[*DependencyNode]int{}
+	var path []*DependencyNode
+
+	var visit func(node *DependencyNode) error
+	visit = func(node *DependencyNode) error {
+		switch state[node] {
+		case visited:
+			return nil
+		case visiting:
+			return newCycleError(path, node)
+		}
+
+		state[node] = visiting
+		path = append(path, node)
+
+		aliases := make([]string, 0, len(node.Requires))
+		for alias := range node.Requires {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+		for _, alias := range aliases {
+			if err := visit(node.Requires[alias]); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[node] = visited
+		if node != root {
+			order = append(order, node)
+		}
+		return nil
+	}
+
+	if err := visit(root); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func newCycleError(path []*DependencyNode, repeated *DependencyNode) error {
+	var start int
+	for i, node := range path {
+		if node == repeated {
+			start = i
+			break
+		}
+	}
+
+	cycle := make([]string, 0, len(path)-start+1)
+	for _, node := range append(path[start:], repeated) {
+		cycle = append(cycle, node.String())
+	}
+	return errors.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> "))
+}
+
+func (n *DependencyNode) String() string {
+	if n.Tag == "" {
+		return n.Alias
+	}
+	return fmt.Sprintf("%s (%s)", n.Alias, n.Tag)
+}
+
+func sortedAliases(deps *Dependencies) []string {
+	aliases := make([]string, 0, len(deps.Requires))
+	for alias := range deps.Requires {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
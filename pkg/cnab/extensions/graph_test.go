@@ -0,0 +1,94 @@
+package extensions
+
+import (
+	"testing"
+
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBundle(name string, requires map[string]string) *bundle.Bundle {
+	bun := &bundle.Bundle{Name: name}
+	if len(requires) > 0 {
+		deps := Dependencies{Requires: make(map[string]Dependency, len(requires))}
+		for alias, tag := range requires {
+			deps.Requires[alias] = Dependency{Bundle: tag}
+		}
+		bun.Custom = map[string]interface{}{DependenciesKey: deps}
+	}
+	return bun
+}
+
+func newTestLoader(bundles map[string]*bundle.Bundle) BundleLoader {
+	return func(tag string) (*bundle.Bundle, error) {
+		bun, ok := bundles[tag]
+		if !ok {
+			return nil, errors.Errorf("bundle %s not found", tag)
+		}
+		return bun, nil
+	}
+}
+
+func getAliases(nodes []*DependencyNode) []string {
+	aliases := make([]string, len(nodes))
+	for i, node := range nodes {
+		aliases[i] = node.Alias
+	}
+	return aliases
+}
+
+func TestDependencySolver_ResolveDependencyGraph(t *testing.T) {
+	bundles := map[string]*bundle.Bundle{
+		"getporter/app:v1":     newTestBundle("app", map[string]string{"db": "getporter/mysql:5.7", "cache": "getporter/redis:5"}),
+		"getporter/mysql:5.7":  newTestBundle("mysql", map[string]string{"storage": "getporter/storage:v1"}),
+		"getporter/redis:5":    newTestBundle("redis", nil),
+		"getporter/storage:v1": newTestBundle("storage", nil),
+	}
+	root := newTestBundle("wordpress", map[string]string{"app": "getporter/app:v1", "mysql": "getporter/mysql:5.7"})
+
+	s := DependencySolver{}
+	graph, err := s.ResolveDependencyGraph(root, newTestLoader(bundles))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"app-cache", "mysql-storage", "mysql", "app"}, getAliases(graph.InstallOrder),
+		"dependencies should be listed after the bundles that they require, and shared bundles listed once")
+
+	mysql := graph.Root.Requires["mysql"]
+	require.NotNil(t, mysql)
+	assert.Equal(t, "getporter/mysql:5.7", mysql.Tag)
+	assert.Same(t, mysql, graph.Root.Requires["app"].Requires["db"], "the mysql bundle should be shared")
+	assert.Equal(t, "getporter/storage:v1", mysql.Requires["storage"].Tag)
+}
+
+func TestDependencySolver_ResolveDependencyGraph_NoDependencies(t *testing.T) {
+	s := DependencySolver{}
+	graph, err := s.ResolveDependencyGraph(newTestBundle("wordpress", nil), newTestLoader(nil))
+	require.NoError(t, err)
+
+	assert.Empty(t, graph.InstallOrder)
+	assert.Equal(t, "wordpress", graph.Root.Alias)
+}
+
+func TestDependencySolver_ResolveDependencyGraph_Cycle(t *testing.T) {
+	bundles := map[string]*bundle.Bundle{
+		"getporter/app:v1":    newTestBundle("app", map[string]string{"db": "getporter/mysql:5.7"}),
+		"getporter/mysql:5.7": newTestBundle("mysql", map[string]string{"backup": "getporter/app:v1"}),
+	}
+	root := newTestBundle("wordpress", map[string]string{"app": "getporter/app:v1"})
+
+	s := DependencySolver{}
+	_, err := s.ResolveDependencyGraph(root, newTestLoader(bundles))
+	require.Error(t, err)
+	assert.EqualError(t, err, "dependency cycle detected: app (getporter/app:v1) -> app-db (getporter/mysql:5.7) -> app (getporter/app:v1)")
+}
+
+func TestDependencySolver_ResolveDependencyGraph_LoadFailed(t *testing.T) {
+	root := newTestBundle("wordpress", map[string]string{"app": "getporter/app:v1"})
+
+	s := DependencySolver{}
+	_, err := s.ResolveDependencyGraph(root, newTestLoader(nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error loading dependency getporter/app:v1")
+}
@@ -0,0 +1,74 @@
+package extensions
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/pkg/errors"
+)
+
+// DependencyLocksKey is the custom extension where Porter records the
+// dependency versions that were resolved when the bundle was built.
+const DependencyLocksKey = "sh.porter.dependencies.lock"
+
+// DependencyLocks is the set of resolved dependencies for a bundle, in the order
+// that they should be installed.
+type DependencyLocks []DependencyLock
+
+// Find the lock for the dependency with the specified alias.
+func (l DependencyLocks) Find(alias string) (DependencyLock, bool) {
+	for _, lock := range l {
+		if lock.Alias == alias {
+			return lock, true
+		}
+	}
+	return DependencyLock{}, false
+}
+
+// Locks returns the resolved version of each dependency in the graph, in the
+// order that they should be installed.
+func (g *DependencyGraph) Locks() DependencyLocks {
+	locks := make(DependencyLocks, len(g.InstallOrder))
+	for i, node := range g.InstallOrder {
+		locks[i] = node.DependencyLock
+	}
+	return locks
+}
+
+// ReadDependencyLocks reads the dependency versions that were locked when the
+// bundle was built. Returns nil when the bundle doesn't have any locks.
+func ReadDependencyLocks(bun *bundle.Bundle) (DependencyLocks, error) {
+	data, ok := bun.Custom[DependencyLocksKey]
+	if !ok {
+		return nil, nil
+	}
+
+	dataB, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not marshal the untyped dependency locks extension data %q", string(dataB))
+	}
+
+	var locks DependencyLocks
+	err = json.Unmarshal(dataB, &locks)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal the dependency locks extension %q", string(dataB))
+	}
+
+	return locks, nil
+}
+
+// DigestBundle calculates the digest of the canonical form of a bundle definition,
+// which is used to detect when the bundle for a locked tag has changed.
+func DigestBundle(bun *bundle.Bundle) (string, error) {
+	var data bytes.Buffer
+	_, err := bun.WriteTo(&data)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not marshal bundle %s", bun.Name)
+	}
+
+	digest := sha256.Sum256(data.Bytes())
+	return "sha256:" + hex.EncodeToString(digest[:]), nil
+}
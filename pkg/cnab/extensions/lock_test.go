@@ -0,0 +1,87 @@
+package extensions
+
+import (
+	"testing"
+
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDependencyLocks(t *testing.T) {
+	locks := DependencyLocks{
+		{Alias: "mysql", Tag: "getporter/mysql:5.7", Digest: "sha256:abc123"},
+	}
+	bun := &bundle.Bundle{
+		Custom: map[string]interface{}{
+			DependencyLocksKey: locks,
+		},
+	}
+
+	got, err := ReadDependencyLocks(bun)
+	require.NoError(t, err)
+	assert.Equal(t, locks, got)
+
+	got, err = ReadDependencyLocks(&bundle.Bundle{})
+	require.NoError(t, err)
+	assert.Nil(t, got, "a bundle without locks should not return any")
+}
+
+func TestDependencySolver_ResolveDependencyGraph_Locked(t *testing.T) {
+	mysql57 := newTestBundle("mysql", nil)
+	mysqlDigest, err := DigestBundle(mysql57)
+	require.NoError(t, err)
+
+	bundles := map[string]*bundle.Bundle{
+		"getporter/mysql:5.7.1":  mysql57,
+		"getporter/mysql:5.7.12": newTestBundle("mysql", nil),
+	}
+	root := &bundle.Bundle{
+		Name: "wordpress",
+		Custom: map[string]interface{}{
+			DependenciesKey: Dependencies{
+				Requires: map[string]Dependency{
+					"mysql": {Bundle: "getporter/mysql", Version: &DependencyVersion{Ranges: []string{"5.7.x"}}},
+				},
+			},
+		},
+	}
+	listTags := func(repo string) ([]string, error) {
+		return []string{"5.7.1", "5.7.12"}, nil
+	}
+
+	t.Run("locked", func(t *testing.T) {
+		s := DependencySolver{
+			ListTags: func(repo string) ([]string, error) {
+				return nil, errors.New("the registry should not be queried for a locked dependency")
+			},
+			Locks: DependencyLocks{{Alias: "mysql", Tag: "getporter/mysql:5.7.1", Digest: mysqlDigest}},
+		}
+		graph, err := s.ResolveDependencyGraph(root, newTestLoader(bundles))
+		require.NoError(t, err)
+
+		assert.Equal(t, DependencyLocks{{Alias: "mysql", Tag: "getporter/mysql:5.7.1", Digest: mysqlDigest}}, graph.Locks())
+	})
+
+	t.Run("lock no longer satisfies the dependency", func(t *testing.T) {
+		s := DependencySolver{
+			ListTags: listTags,
+			Locks:    DependencyLocks{{Alias: "mysql", Tag: "getporter/mysql:5.6.1"}},
+		}
+		graph, err := s.ResolveDependencyGraph(root, newTestLoader(bundles))
+		require.NoError(t, err)
+
+		assert.Equal(t, "getporter/mysql:5.7.12", graph.Root.Requires["mysql"].Tag)
+	})
+
+	t.Run("locked bundle changed", func(t *testing.T) {
+		s := DependencySolver{
+			ListTags: listTags,
+			Locks:    DependencyLocks{{Alias: "mysql", Tag: "getporter/mysql:5.7.1", Digest: "sha256:abc123"}},
+		}
+		_, err := s.ResolveDependencyGraph(root, newTestLoader(bundles))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the bundle for dependency mysql (getporter/mysql:5.7.1) has changed since it was locked")
+	})
+}
@@ -0,0 +1,81 @@
+package extensions
+
+import (
+	"encoding/json"
+
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/pkg/errors"
+)
+
+// DependencyParametersKey is the custom extension where Porter records the
+// parameter values that a bundle passes to its dependencies.
+const DependencyParametersKey = "sh.porter.dependencies.parameters"
+
+// DependencyParameters are the parameter values that a bundle passes to its
+// dependencies, keyed by the dependency alias and then the parameter name.
+// Values may be templates that reference the parameters and credentials of the
+// bundle, for example {{ bundle.parameters.region }}.
+type DependencyParameters map[string]map[string]string
+
+// ReadDependencyParameters reads the parameter values that the bundle passes
+// to its dependencies. Returns nil when the bundle doesn't define any.
+func ReadDependencyParameters(bun *bundle.Bundle) (DependencyParameters, error) {
+	data, ok := bun.Custom[DependencyParametersKey]
+	if !ok {
+		return nil, nil
+	}
+
+	dataB, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not marshal the untyped dependency parameters extension data %q", string(dataB))
+	}
+
+	var params DependencyParameters
+	err = json.Unmarshal(dataB, &params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal the dependency parameters extension %q", string(dataB))
+	}
+
+	return params, nil
+}
+
+// ParameterSourcesKey is the custom extension where Porter records the sources
+// of the default values of the bundle's parameters.
+const ParameterSourcesKey = "sh.porter.parameter-sources"
+
+// ParameterSources are the sources of the default values of the bundle's
+// parameters, keyed by the parameter name.
+type ParameterSources map[string]ParameterSource
+
+// ParameterSource is an output of an installation that is used as the default
+// value of a parameter, when a value is not specified.
+type ParameterSource struct {
+	// Installation is the name of the installation with the output. When empty,
+	// the output from the previous run of the installation being executed is used.
+	Installation string `json:"installation,omitempty"`
+
+	// Output is the name of the output.
+	Output string `json:"output"`
+}
+
+// ReadParameterSources reads the sources of the default values of the bundle's
+// parameters. Returns nil when the bundle doesn't define any.
+func ReadParameterSources(bun *bundle.Bundle) (ParameterSources, error) {
+	data, ok := bun.Custom[ParameterSourcesKey]
+	if !ok {
+		return nil, nil
+	}
+
+	dataB, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not marshal the untyped parameter sources extension data %q", string(dataB))
+	}
+
+	var sources ParameterSources
+	err = json.Unmarshal(dataB, &sources)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal the parameter sources extension %q", string(dataB))
+	}
+
+	return sources, nil
+}
@@ -2,23 +2,42 @@ package extensions
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/Masterminds/semver"
 	"github.com/cnabio/cnab-go/bundle"
+	"github.com/docker/distribution/reference"
 	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/pkg/errors"
 )
 
+// DependencyLock records the version of a dependency that was resolved.
 type DependencyLock struct {
-	Alias string
-	Tag   string
+	// Alias of the dependency.
+	Alias string `json:"alias" yaml:"alias"`
+
+	// Tag of the dependency bundle, in the format REGISTRY/NAME:TAG.
+	Tag string `json:"tag" yaml:"tag"`
+
+	// Digest of the dependency bundle definition, see DigestBundle.
+	Digest string `json:"digest,omitempty" yaml:"digest,omitempty"`
 }
 
 type DependencySolver struct {
+	// ListTags returns the tags available for a bundle repository.
+	// Defaults to querying the registry when not set.
+	ListTags func(repo string) ([]string, error)
+
+	// Locks are dependency versions resolved previously. A locked version is
+	// used instead of resolving the version again, as long as it still satisfies
+	// the dependency.
+	Locks DependencyLocks
 }
 
+// ResolveDependencies determines the tag of each dependency of the bundle, using the
+// locked tag when it still satisfies the dependency.
 func (s *DependencySolver) ResolveDependencies(bun *bundle.Bundle) ([]DependencyLock, error) {
 	deps, err := ReadDependencies(bun)
 	if deps == nil {
@@ -30,14 +49,18 @@ func (s *DependencySolver) ResolveDependencies(bun *bundle.Bundle) ([]Dependency
 
 	q := make([]DependencyLock, 0, len(deps.Requires))
 	for alias, dep := range deps.Requires {
-		bundle := strings.Split(dep.Bundle, ":")[0]
-		version, err := s.ResolveVersion(alias, dep)
+		if lock, ok := s.Locks.Find(alias); ok && satisfies(alias, dep, lock.Tag) {
+			q = append(q, lock)
+			continue
+		}
+
+		tag, err := s.resolveTag(alias, dep)
 		if err != nil {
 			return nil, err
 		}
 		lock := DependencyLock{
 			Alias: alias,
-			Tag:   fmt.Sprintf("%s:%s", bundle, version),
+			Tag:   tag,
 		}
 		q = append(q, lock)
 	}
@@ -45,22 +68,60 @@ func (s *DependencySolver) ResolveDependencies(bun *bundle.Bundle) ([]Dependency
 	return q, nil
 }
 
+// resolveTag determines the full REGISTRY/NAME:TAG reference for a dependency.
+func (s *DependencySolver) resolveTag(alias string, dep Dependency) (string, error) {
+	repo, _, err := splitTag(dep.Bundle)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid bundle %s for dependency %s", dep.Bundle, alias)
+	}
+	version, err := s.ResolveVersion(alias, dep)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s", repo, version), nil
+}
+
+// splitTag splits a bundle reference, in the format REGISTRY/NAME:TAG, into the repository
+// and the tag, which is empty when the reference does not have a tag. The registry may
+// include a port, such as localhost:5000/mysql.
+func splitTag(ref string) (string, string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", "", err
+	}
+
+	tagged, ok := named.(reference.Tagged)
+	if !ok {
+		return ref, "", nil
+	}
+	return strings.TrimSuffix(ref, ":"+tagged.Tag()), tagged.Tag(), nil
+}
+
 func (s *DependencySolver) ResolveVersion(alias string, dep Dependency) (string, error) {
 	// Here is where we could split out this logic into multiple strategy funcs / structs if necessary
 	if dep.Version == nil || len(dep.Version.Ranges) == 0 {
-		parts := strings.Split(dep.Bundle, ":")
-		if len(parts) > 1 {
-			return strings.Join(parts[1:], ""), nil
-		} else {
-			return s.determineDefaultTag(dep)
+		_, tag, err := splitTag(dep.Bundle)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid bundle %s for dependency %s", dep.Bundle, alias)
+		}
+		if tag != "" {
+			return tag, nil
 		}
+		return s.determineDefaultTag(dep)
 	}
 
-	return "", errors.Errorf("not implemented: dependency version range specified for %s", alias)
+	return s.determineTagFromRanges(alias, dep)
+}
+
+func (s *DependencySolver) listTags(repo string) ([]string, error) {
+	if s.ListTags != nil {
+		return s.ListTags(repo)
+	}
+	return crane.ListTags(repo)
 }
 
 func (s *DependencySolver) determineDefaultTag(dep Dependency) (string, error) {
-	tags, err := crane.ListTags(dep.Bundle)
+	tags, err := s.listTags(dep.Bundle)
 	if err != nil {
 		return "", errors.Wrapf(err, "error listing tags for %s", dep.Bundle)
 	}
@@ -99,3 +160,142 @@ func (s *DependencySolver) determineDefaultTag(dep Dependency) (string, error) {
 
 	return versions[0].Original(), nil
 }
+
+// determineTagFromRanges selects the highest tag in the registry that
+// satisfies at least one of the version ranges declared on the dependency.
+func (s *DependencySolver) determineTagFromRanges(alias string, dep Dependency) (string, error) {
+	_, tag, err := splitTag(dep.Bundle)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid bundle %s for dependency %s", dep.Bundle, alias)
+	}
+	if tag != "" {
+		return "", errors.Errorf("dependency %s cannot specify both a tag and version ranges", alias)
+	}
+
+	constraints, err := parseRanges(alias, dep.Version)
+	if err != nil {
+		return "", err
+	}
+
+	tags, err := s.listTags(dep.Bundle)
+	if err != nil {
+		return "", errors.Wrapf(err, "error listing tags for %s", dep.Bundle)
+	}
+
+	versions := make(semver.Collection, 0, len(tags))
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+
+		if satisfiesRanges(version, constraints, dep.Version.Ranges, dep.Version.AllowPrereleases) {
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		return "", errors.Errorf("none of the tags defined in the registry for %s satisfy the version ranges %s for dependency %s",
+			dep.Bundle, strings.Join(dep.Version.Ranges, ", "), alias)
+	}
+
+	sort.Sort(sort.Reverse(versions))
+
+	return versions[0].Original(), nil
+}
+
+func parseRanges(alias string, version *DependencyVersion) ([]*semver.Constraints, error) {
+	constraints := make([]*semver.Constraints, 0, len(version.Ranges))
+	for _, r := range version.Ranges {
+		c, err := semver.NewConstraint(r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid version range %q for dependency %s", r, alias)
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints, nil
+}
+
+// rangeVersionRegex matches the versions in a version range, such as 1.2.3, v1.x or 1.0.0-beta.1.
+var rangeVersionRegex = regexp.MustCompile(`v?[0-9]+(\.[0-9xX*]+){0,2}(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?`)
+
+// satisfiesRanges determines if a version matches at least one of the constraints.
+func satisfiesRanges(version *semver.Version, constraints []*semver.Constraints, ranges []string, allowPrereleases bool) bool {
+	if version.Prerelease() != "" {
+		if !allowPrereleases {
+			return false
+		}
+
+		// A prerelease never satisfies a constraint on a release, so lift that restriction
+		// by giving the releases in the ranges a prerelease that sorts just after the
+		// version's own prerelease. The versions are still compared in the same order,
+		// for example 1.0.0-rc1 is less than 1.0.0 and does not satisfy >=1.0.0.
+		var err error
+		constraints, err = parsePrereleaseRanges(ranges, version.Prerelease()+".0")
+		if err != nil {
+			return false
+		}
+	}
+
+	for _, c := range constraints {
+		if c.Check(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePrereleaseRanges parses version ranges, adding the prerelease to the versions in them that are releases.
+func parsePrereleaseRanges(ranges []string, prerelease string) ([]*semver.Constraints, error) {
+	constraints := make([]*semver.Constraints, 0, len(ranges))
+	for _, r := range ranges {
+		r = rangeVersionRegex.ReplaceAllStringFunc(r, func(version string) string {
+			parts := strings.SplitN(version, "+", 2)
+			if strings.Contains(parts[0], "-") {
+				return version
+			}
+			parts[0] += "-" + prerelease
+			return strings.Join(parts, "+")
+		})
+
+		c, err := semver.NewConstraint(r)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints, nil
+}
+
+// satisfies determines if a resolved tag, in the format REGISTRY/NAME:TAG,
+// meets the requirements of the dependency.
+func satisfies(alias string, dep Dependency, tag string) bool {
+	repo, version, err := splitTag(tag)
+	if err != nil || version == "" {
+		return false
+	}
+	depRepo, depTag, err := splitTag(dep.Bundle)
+	if err != nil || repo != depRepo {
+		return false
+	}
+
+	// A pinned tag must match exactly
+	if depTag != "" {
+		return depTag == version
+	}
+
+	// Without any ranges, any tag from the repository is acceptable
+	if dep.Version == nil || len(dep.Version.Ranges) == 0 {
+		return true
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	constraints, err := parseRanges(alias, dep.Version)
+	if err != nil {
+		return false
+	}
+	return satisfiesRanges(v, constraints, dep.Version.Ranges, dep.Version.AllowPrereleases)
+}
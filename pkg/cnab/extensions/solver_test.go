@@ -44,9 +44,9 @@ func TestDependencySolver_ResolveVersion(t *testing.T) {
 		{name: "pinned version",
 			dep:         Dependency{"mysql:5.7", nil},
 			wantVersion: "5.7"},
-		{name: "unimplemented range",
-			dep:       Dependency{"mysql", &DependencyVersion{Ranges: []string{"1 - 1.5"}}},
-			wantError: "not implemented"},
+		{name: "range with a pinned tag",
+			dep:       Dependency{"mysql:5.7", &DependencyVersion{Ranges: []string{"1 - 1.5"}}},
+			wantError: "cannot specify both a tag and version ranges"},
 		{name: "default tag to latest",
 			dep:         Dependency{Bundle: "getporterci/porter-test-only-latest"},
 			wantVersion: "latest"},
@@ -80,3 +80,169 @@ func TestDependencySolver_ResolveVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestDependencySolver_ResolveVersion_Ranges(t *testing.T) {
+	tags := []string{"latest", "v1.0", "v1.1.3", "v1.2", "v1.3-beta1", "v2.0.0", "not-semver"}
+
+	testcases := []struct {
+		name        string
+		version     DependencyVersion
+		wantVersion string
+		wantError   string
+	}{
+		{name: "highest match",
+			version:     DependencyVersion{Ranges: []string{"1.x"}},
+			wantVersion: "v1.2"},
+		{name: "hyphen range",
+			version:     DependencyVersion{Ranges: []string{"1.0 - 1.1.5"}},
+			wantVersion: "v1.1.3"},
+		{name: "any range can match",
+			version:     DependencyVersion{Ranges: []string{"~1.0", "^2"}},
+			wantVersion: "v2.0.0"},
+		{name: "prereleases allowed",
+			version:     DependencyVersion{Ranges: []string{"1.x"}, AllowPrereleases: true},
+			wantVersion: "v1.3-beta1"},
+		{name: "prerelease of the lower bound",
+			version:   DependencyVersion{Ranges: []string{">=1.3, <2.0.0"}, AllowPrereleases: true},
+			wantError: "none of the tags defined in the registry for getporter/mysql satisfy the version ranges >=1.3, <2.0.0 for dependency mysql"},
+		{name: "no matching tags",
+			version:   DependencyVersion{Ranges: []string{">=3"}},
+			wantError: "none of the tags defined in the registry for getporter/mysql satisfy the version ranges >=3 for dependency mysql"},
+		{name: "invalid range",
+			version:   DependencyVersion{Ranges: []string{"oops"}},
+			wantError: `invalid version range "oops" for dependency mysql`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := DependencySolver{
+				ListTags: func(repo string) ([]string, error) {
+					assert.Equal(t, "getporter/mysql", repo)
+					return tags, nil
+				},
+			}
+			version, err := s.ResolveVersion("mysql", Dependency{"getporter/mysql", &tc.version})
+
+			if tc.wantError != "" {
+				require.Error(t, err, "ResolveVersion should have returned an error")
+				assert.Contains(t, err.Error(), tc.wantError)
+			} else {
+				require.NoError(t, err, "ResolveVersion should not have returned an error")
+				assert.Equal(t, tc.wantVersion, version, "incorrect version resolved")
+			}
+		})
+	}
+}
+
+func TestDependencySolver_ResolveDependencies_Ranges(t *testing.T) {
+	bun := &bundle.Bundle{
+		Custom: map[string]interface{}{
+			DependenciesKey: Dependencies{
+				Requires: map[string]Dependency{
+					"mysql": {
+						Bundle:  "getporter/mysql",
+						Version: &DependencyVersion{Ranges: []string{"5.7.x"}},
+					},
+				},
+			},
+		},
+	}
+
+	s := DependencySolver{
+		ListTags: func(repo string) ([]string, error) {
+			return []string{"5.6.1", "5.7.1", "5.7.12", "8.0.0"}, nil
+		},
+	}
+	locks, err := s.ResolveDependencies(bun)
+	require.NoError(t, err)
+
+	require.Len(t, locks, 1)
+	assert.Equal(t, "mysql", locks[0].Alias)
+	assert.Equal(t, "getporter/mysql:5.7.12", locks[0].Tag)
+}
+
+func TestDependencySolver_ResolveVersion_RegistryPort(t *testing.T) {
+	s := DependencySolver{
+		ListTags: func(repo string) ([]string, error) {
+			assert.Equal(t, "localhost:5000/mysql", repo)
+			return []string{"5.6.1", "5.7.1"}, nil
+		},
+	}
+	ranges := &DependencyVersion{Ranges: []string{"5.7.x"}}
+
+	version, err := s.ResolveVersion("mysql", Dependency{"localhost:5000/mysql", ranges})
+	require.NoError(t, err, "a registry with a port is not a tag")
+	assert.Equal(t, "5.7.1", version)
+
+	version, err = s.ResolveVersion("mysql", Dependency{"localhost:5000/mysql:5.6.1", nil})
+	require.NoError(t, err)
+	assert.Equal(t, "5.6.1", version)
+
+	_, err = s.ResolveVersion("mysql", Dependency{"localhost:5000/mysql:5.7.1", ranges})
+	require.EqualError(t, err, "dependency mysql cannot specify both a tag and version ranges")
+}
+
+func TestSatisfies(t *testing.T) {
+	ranges := &DependencyVersion{Ranges: []string{"5.7.x"}}
+	prereleases := &DependencyVersion{Ranges: []string{">=1.0.0, <=2.0.0"}, AllowPrereleases: true}
+
+	testcases := []struct {
+		name string
+		dep  Dependency
+		tag  string
+		want bool
+	}{
+		{name: "any tag", dep: Dependency{Bundle: "getporter/mysql"}, tag: "getporter/mysql:5.6.1", want: true},
+		{name: "different repository", dep: Dependency{Bundle: "getporter/mysql"}, tag: "getporter/postgres:5.6.1", want: false},
+		{name: "pinned tag", dep: Dependency{Bundle: "getporter/mysql:5.7.1"}, tag: "getporter/mysql:5.7.1", want: true},
+		{name: "different pinned tag", dep: Dependency{Bundle: "getporter/mysql:5.7.1"}, tag: "getporter/mysql:5.6.1", want: false},
+		{name: "in range", dep: Dependency{Bundle: "getporter/mysql", Version: ranges}, tag: "getporter/mysql:5.7.12", want: true},
+		{name: "out of range", dep: Dependency{Bundle: "getporter/mysql", Version: ranges}, tag: "getporter/mysql:5.6.1", want: false},
+		{name: "registry port", dep: Dependency{Bundle: "localhost:5000/mysql"}, tag: "localhost:5000/mysql:5.6.1", want: true},
+		{name: "registry port in range", dep: Dependency{Bundle: "localhost:5000/mysql", Version: ranges}, tag: "localhost:5000/mysql:5.7.1", want: true},
+		{name: "registry port pinned tag", dep: Dependency{Bundle: "localhost:5000/mysql:5.7.1"}, tag: "localhost:5000/mysql:5.7.1", want: true},
+		{name: "prerelease not allowed", dep: Dependency{Bundle: "getporter/mysql", Version: &DependencyVersion{Ranges: []string{">=1.0.0"}}}, tag: "getporter/mysql:1.1.0-rc1", want: false},
+		{name: "prerelease in range", dep: Dependency{Bundle: "getporter/mysql", Version: prereleases}, tag: "getporter/mysql:1.1.0-rc1", want: true},
+		{name: "prerelease before range", dep: Dependency{Bundle: "getporter/mysql", Version: prereleases}, tag: "getporter/mysql:1.0.0-rc1", want: false},
+		{name: "prerelease before upper bound", dep: Dependency{Bundle: "getporter/mysql", Version: prereleases}, tag: "getporter/mysql:2.0.0-rc1", want: true},
+		{name: "prerelease in range with prerelease", dep: Dependency{Bundle: "getporter/mysql", Version: &DependencyVersion{Ranges: []string{">=1.0.0-rc2"}, AllowPrereleases: true}}, tag: "getporter/mysql:1.0.0-rc3", want: true},
+		{name: "prerelease before range with prerelease", dep: Dependency{Bundle: "getporter/mysql", Version: &DependencyVersion{Ranges: []string{">=1.0.0-rc2"}, AllowPrereleases: true}}, tag: "getporter/mysql:1.0.0-rc1", want: false},
+		{name: "registry port without a tag", dep: Dependency{Bundle: "localhost:5000/mysql"}, tag: "localhost:5000/mysql", want: false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, satisfies("mysql", tc.dep, tc.tag))
+		})
+	}
+}
+
+func TestDependencySolver_ResolveDependencies_Locks(t *testing.T) {
+	bun := &bundle.Bundle{
+		Custom: map[string]interface{}{
+			DependenciesKey: Dependencies{
+				Requires: map[string]Dependency{
+					"mysql": {
+						Bundle:  "getporter/mysql",
+						Version: &DependencyVersion{Ranges: []string{"5.7.x"}},
+					},
+				},
+			},
+		},
+	}
+
+	s := DependencySolver{
+		ListTags: func(repo string) ([]string, error) {
+			return []string{"5.6.1", "5.7.1", "5.7.12"}, nil
+		},
+		Locks: DependencyLocks{{Alias: "mysql", Tag: "getporter/mysql:5.7.1", Digest: "sha256:abc123"}},
+	}
+	locks, err := s.ResolveDependencies(bun)
+	require.NoError(t, err)
+	assert.Equal(t, []DependencyLock{{Alias: "mysql", Tag: "getporter/mysql:5.7.1", Digest: "sha256:abc123"}}, locks, "the locked version should be used")
+
+	s.Locks = DependencyLocks{{Alias: "mysql", Tag: "getporter/mysql:5.6.1"}}
+	locks, err = s.ResolveDependencies(bun)
+	require.NoError(t, err)
+	assert.Equal(t, []DependencyLock{{Alias: "mysql", Tag: "getporter/mysql:5.7.12"}}, locks, "a locked version that doesn't satisfy the dependency should be resolved again")
+}
@@ -37,6 +37,17 @@ type ActionArguments struct {
 
 	// Path to an optional relocation mapping file
 	RelocationMapping string
+
+	// Resume skips the steps that completed in the last failed run of the action.
+	Resume bool
+
+	// RollbackTo is the id of the run that the installation is rolled back to,
+	// which is recorded in the history of the installation.
+	RollbackTo string
+
+	// SharedDependencies are the existing installations used for the dependencies of
+	// the bundle, keyed by the dependency alias, which are recorded on the claim.
+	SharedDependencies map[string]string
 }
 
 func (d *Runtime) ApplyConfig(args ActionArguments) action.OperationConfigs {
@@ -44,6 +55,7 @@ func (d *Runtime) ApplyConfig(args ActionArguments) action.OperationConfigs {
 		d.SetOutput(),
 		d.AddFiles(args),
 		d.AddRelocation(args),
+		d.SetResume(args),
 	}
 }
 
@@ -75,6 +87,16 @@ func (d *Runtime) AddFiles(args ActionArguments) action.OperationConfigFunc {
 	}
 }
 
+// SetResume signals to the Porter runtime that it should resume the last failed run of the action.
+func (d *Runtime) SetResume(args ActionArguments) action.OperationConfigFunc {
+	return func(op *driver.Operation) error {
+		if args.Resume {
+			op.Environment[config.EnvResume] = "true"
+		}
+		return nil
+	}
+}
+
 // AddRelocation operates on an ActionArguments and adds any provided relocation mapping
 // to the operation's files.
 func (d *Runtime) AddRelocation(args ActionArguments) action.OperationConfigFunc {
@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"get.porter.sh/porter/pkg/config"
 	"github.com/cnabio/cnab-go/bundle"
 	"github.com/cnabio/cnab-go/driver"
 	"github.com/stretchr/testify/assert"
@@ -40,3 +41,16 @@ func TestAddReloccation(t *testing.T) {
 	assert.Equal(t, "my.registry/microservice@sha256:cca460afa270d4c527981ef9ca4989346c56cf9b20217dcea37df1ece8120687", op.Image.Image)
 
 }
+
+func TestSetResume(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	op := &driver.Operation{Environment: map[string]string{}}
+	err := d.SetResume(ActionArguments{})(op)
+	require.NoError(t, err)
+	assert.NotContains(t, op.Environment, config.EnvResume)
+
+	err = d.SetResume(ActionArguments{Resume: true})(op)
+	require.NoError(t, err)
+	assert.Equal(t, "true", op.Environment[config.EnvResume])
+}
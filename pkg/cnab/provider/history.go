@@ -0,0 +1,47 @@
+package cnabprovider
+
+import (
+	"os/user"
+
+	"get.porter.sh/porter/pkg/claims"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/pkg/errors"
+)
+
+// recordRun adds the last action executed against the claim, and its logs, to the history of
+// the installation. The run is only recorded when the action was executed, which updates the
+// revision of the claim, and not when it failed before the bundle was run.
+//
+// The bundle and parameters of successful runs are saved in a snapshot, so that the
+// installation can be rolled back to the run.
+func (d *Runtime) recordRun(c claim.Claim, args ActionArguments, previousRevision string, logs string) error {
+	if c.Revision == previousRevision {
+		return nil
+	}
+
+	run := claims.NewRun(c)
+	run.RollbackTo = args.RollbackTo
+	if u, err := user.Current(); err == nil {
+		run.User = u.Username
+	}
+
+	err := d.claims.SaveRun(run)
+	if err != nil {
+		return errors.Wrapf(err, "failed to record the %s action in the history of bundle instance %s", c.Result.Action, c.Name)
+	}
+
+	err = d.claims.SaveLogs(c.Name, run.ID, logs)
+	if err != nil {
+		return errors.Wrapf(err, "failed to save the logs of the %s action for bundle instance %s", c.Result.Action, c.Name)
+	}
+
+	if c.Result.Status != claim.StatusSuccess || c.Result.Action == claim.ActionUninstall {
+		return nil
+	}
+	snapshot := claims.Snapshot{
+		Bundle:     c.Bundle,
+		Parameters: c.Parameters,
+	}
+	err = d.claims.SaveSnapshot(c.Name, run.ID, snapshot)
+	return errors.Wrapf(err, "failed to save the snapshot of the %s action for bundle instance %s", c.Result.Action, c.Name)
+}
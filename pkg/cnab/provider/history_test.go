@@ -0,0 +1,124 @@
+package cnabprovider
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"get.porter.sh/porter/pkg/claims"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntime_RecordsHistory(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	args := ActionArguments{
+		Claim:      "mybuns",
+		BundlePath: "testdata/bundle.json",
+		Insecure:   true,
+		Driver:     "debug",
+	}
+
+	require.NoError(t, d.Install(args))
+	rollbackArgs := args
+	rollbackArgs.RollbackTo = "01E2ZZ0"
+	require.NoError(t, d.Upgrade(rollbackArgs))
+	require.NoError(t, d.Invoke("zombies", args))
+	require.NoError(t, d.Uninstall(args))
+
+	runs, err := d.claims.ReadHistory("mybuns")
+	require.NoError(t, err)
+	require.Len(t, runs, 4, "every action should be recorded, and the history should be kept after the bundle is uninstalled")
+
+	wantActions := []string{claim.ActionInstall, claim.ActionUpgrade, "zombies", claim.ActionUninstall}
+	for i, run := range runs {
+		assert.Equal(t, wantActions[i], run.Action)
+		assert.Equal(t, claim.StatusSuccess, run.Status)
+		assert.Equal(t, "mybuns", run.BundleName)
+		assert.Equal(t, "v1.0.0", run.BundleVersion)
+		assert.NotEmpty(t, run.ID)
+	}
+	assert.Equal(t, "01E2ZZ0", runs[1].RollbackTo, "expected the rollback to be recorded")
+
+	snapshot, err := d.claims.ReadSnapshot("mybuns", runs[0].ID)
+	require.NoError(t, err, "expected a snapshot of the successful install")
+	require.NotNil(t, snapshot.Bundle)
+	assert.Equal(t, "v1.0.0", snapshot.Bundle.Version)
+
+	_, err = d.claims.ReadSnapshot("mybuns", runs[3].ID)
+	assert.Equal(t, claims.ErrSnapshotNotFound, err, "uninstall should not be snapshotted")
+}
+
+func TestRuntime_RecordsHistory_NotExecuted(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	args := ActionArguments{
+		Claim:      "mybuns",
+		BundlePath: "testdata/bundle.json",
+		Insecure:   true,
+		Driver:     "debug",
+		Params:     map[string]string{"missing": "value"},
+	}
+
+	err := d.Install(args)
+	require.Error(t, err)
+
+	runs, err := d.claims.ReadHistory("mybuns")
+	require.NoError(t, err)
+	assert.Empty(t, runs, "actions that fail before the bundle is run should not be recorded")
+}
+
+func TestRuntime_RecordsLogs(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	sensitive := true
+	bun := bundle.Bundle{
+		SchemaVersion: "v1.0.0",
+		Name:          "mybuns",
+		Version:       "v1.0.0",
+		InvocationImages: []bundle.InvocationImage{
+			{BaseImage: bundle.BaseImage{Image: "mybuns:latest", ImageType: "docker"}},
+		},
+		Definitions: definition.Definitions{
+			"password": &definition.Schema{Type: "string", WriteOnly: &sensitive},
+			"string":   &definition.Schema{Type: "string"},
+		},
+		Parameters: map[string]bundle.Parameter{
+			"password": {Definition: "password"},
+			"region":   {Definition: "string"},
+		},
+	}
+	bundleDir, err := ioutil.TempDir("", "porter")
+	require.NoError(t, err)
+	defer os.RemoveAll(bundleDir)
+	bundlePath := filepath.Join(bundleDir, "bundle.json")
+	data, err := json.Marshal(bun)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(bundlePath, data, 0644))
+
+	args := ActionArguments{
+		Claim:      "mybuns",
+		BundlePath: bundlePath,
+		Insecure:   true,
+		Driver:     "debug",
+		Params:     map[string]string{"password": "s3cr3t", "region": "eastus"},
+	}
+	require.NoError(t, d.Install(args))
+
+	runs, err := d.claims.ReadHistory("mybuns")
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+
+	// The debug driver prints the operation, including its parameters
+	logs, err := d.claims.ReadLogs("mybuns", runs[0].ID)
+	require.NoError(t, err)
+	assert.Contains(t, logs, "eastus")
+	assert.NotContains(t, logs, "s3cr3t", "sensitive parameters should be censored in the logs")
+	assert.Contains(t, logs, "*******")
+}
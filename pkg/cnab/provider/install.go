@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 
+	"get.porter.sh/porter/pkg/claims"
 	"get.porter.sh/porter/pkg/manifest"
 )
 
@@ -28,7 +29,26 @@ func (d *Runtime) Install(args ActionArguments) error {
 	}
 	c.Bundle = b
 
-	params, err := d.loadParameters(c, args.Params, string(manifest.ActionInstall))
+	if args.Resume {
+		// Keep the custom data from the failed run, which includes the steps to skip
+		existing, err := d.claims.Read(args.Claim)
+		if err != nil {
+			return errors.Wrapf(err, "could not load bundle instance %s to resume", args.Claim)
+		}
+		c.Custom = existing.Custom
+	}
+
+	err = claims.SetSharedDependencies(c, args.SharedDependencies)
+	if err != nil {
+		return err
+	}
+
+	sourced, err := d.resolveParameterSources(c, args.Params)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve parameter sources")
+	}
+
+	params, err := d.loadParameters(c, args.Params, sourced, string(manifest.ActionInstall))
 	if err != nil {
 		return errors.Wrap(err, "invalid parameters")
 	}
@@ -63,16 +83,28 @@ func (d *Runtime) Install(args ActionArguments) error {
 
 	var result *multierror.Error
 	// Install and capture error
-	err = i.Run(c, creds, d.ApplyConfig(args)...)
+	revision := c.Revision
+	logs, captureLogs := d.captureLogs(dvr, c, creds)
+	err = i.Run(c, creds, append(d.ApplyConfig(args), captureLogs)...)
 	if err != nil {
 		result = multierror.Append(result, errors.Wrap(err, "failed to install the bundle"))
 	}
 
+	err = claims.RecordActionProgress(c)
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	// ALWAYS write out a claim, even if the installation fails
 	err = d.claims.Save(*c)
 	if err != nil {
 		result = multierror.Append(result, errors.Wrap(err, "failed to record the installation for the bundle"))
 	}
 
+	err = d.recordRun(*c, args, revision, logs.String())
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	return result.ErrorOrNil()
 }
@@ -3,6 +3,7 @@ package cnabprovider
 import (
 	"fmt"
 
+	"get.porter.sh/porter/pkg/claims"
 	cnabaction "github.com/cnabio/cnab-go/action"
 	"github.com/cnabio/cnab-go/bundle"
 	"github.com/cnabio/cnab-go/claim"
@@ -60,7 +61,12 @@ func (d *Runtime) Invoke(action string, args ActionArguments) error {
 		c.Bundle = bun
 	}
 
-	c.Parameters, err = d.loadParameters(c, args.Params, action)
+	sourced, err := d.resolveParameterSources(c, args.Params)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve parameter sources")
+	}
+
+	c.Parameters, err = d.loadParameters(c, args.Params, sourced, action)
 	if err != nil {
 		return errors.Wrap(err, "invalid parameters")
 	}
@@ -96,14 +102,29 @@ func (d *Runtime) Invoke(action string, args ActionArguments) error {
 
 	var result *multierror.Error
 	// Run the action and ALWAYS write out a claim, even if the action fails
-	err = i.Run(c, creds, d.ApplyConfig(args)...)
+	revision := c.Revision
+	logs, captureLogs := d.captureLogs(driver, c, creds)
+	err = i.Run(c, creds, append(d.ApplyConfig(args), captureLogs)...)
 	if err != nil {
 		result = multierror.Append(result, errors.Wrap(err, "failed to invoke the bundle"))
 	}
 
+	err = claims.RecordActionProgress(c)
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	err = d.writeClaim(isTemp, c)
 	if err != nil {
 		result = multierror.Append(result, errors.Wrap(err, "failed to record the updated claim for the bundle"))
 	}
+
+	// Stateless actions don't have an installation to record the run against
+	if !isTemp {
+		err = d.recordRun(*c, args, revision, logs.String())
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
 	return result.ErrorOrNil()
 }
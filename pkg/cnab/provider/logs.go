@@ -0,0 +1,60 @@
+package cnabprovider
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"get.porter.sh/porter/pkg/context"
+	"github.com/cnabio/cnab-go/action"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/cnabio/cnab-go/credentials"
+	"github.com/cnabio/cnab-go/driver"
+	"github.com/cnabio/cnab-go/driver/docker"
+)
+
+// captureLogs copies the output of an action, from both the driver and the invocation image,
+// into logs so that they can be saved with the history of the installation. The values of the
+// credentials and of sensitive parameters are censored in the logs.
+//
+// Returns the logs and the configuration to apply to the operation.
+func (d *Runtime) captureLogs(dvr driver.Driver, c *claim.Claim, creds credentials.Set) (*bytes.Buffer, action.OperationConfigFunc) {
+	logs := &bytes.Buffer{}
+	censoredLogs := context.NewCensoredWriter(logs)
+	censoredLogs.SetSensitiveValues(sensitiveValues(c, creds))
+
+	// The docker driver writes the output of the container directly to stdout and stderr
+	if dockerDriver, ok := dvr.(*docker.Driver); ok {
+		dockerDriver.SetContainerOut(io.MultiWriter(d.Out, censoredLogs))
+		dockerDriver.SetContainerErr(io.MultiWriter(d.Err, censoredLogs))
+	}
+
+	return logs, func(op *driver.Operation) error {
+		op.Out = io.MultiWriter(op.Out, censoredLogs)
+		return nil
+	}
+}
+
+// sensitiveValues returns the values of the credentials and sensitive parameters of an action.
+func sensitiveValues(c *claim.Claim, creds credentials.Set) []string {
+	values := make([]string, 0, len(creds))
+	for _, value := range creds {
+		values = append(values, value)
+	}
+
+	if c.Bundle == nil {
+		return values
+	}
+	for name, value := range c.Parameters {
+		param, ok := c.Bundle.Parameters[name]
+		if !ok {
+			continue
+		}
+		def, ok := c.Bundle.Definitions[param.Definition]
+		if !ok || def.WriteOnly == nil || !*def.WriteOnly {
+			continue
+		}
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	return values
+}
@@ -3,23 +3,110 @@ package cnabprovider
 import (
 	"encoding/base64"
 	"fmt"
+	"sort"
+	"strings"
 
+	"get.porter.sh/porter/pkg/cnab/extensions"
+	"get.porter.sh/porter/pkg/parameters"
 	"github.com/cnabio/cnab-go/bundle"
 	"github.com/cnabio/cnab-go/bundle/definition"
 	"github.com/cnabio/cnab-go/claim"
 	"github.com/pkg/errors"
 )
 
+// resolveParameterSources returns a value for each parameter that has a source and was not
+// specified. The value is read from the outputs of the installation's previous run, or of
+// another installation. When the installation or the output does not exist, the parameter
+// is left unset so that its default is used.
+func (d *Runtime) resolveParameterSources(c *claim.Claim, rawOverrides map[string]string) (map[string]string, error) {
+	sources, err := extensions.ReadParameterSources(c.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string, len(sources))
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Read each installation once, the previous run of this installation is the claim in storage
+	installations := map[string]*claim.Claim{}
+	for _, name := range names {
+		if _, ok := rawOverrides[name]; ok {
+			continue
+		}
+
+		source := sources[name]
+		installation := source.Installation
+		if installation == "" {
+			installation = c.Name
+		}
+
+		sourceClaim, ok := installations[installation]
+		if !ok {
+			existing, err := d.claims.Read(installation)
+			if err != nil && err != claim.ErrClaimNotFound {
+				return nil, errors.Wrapf(err, "could not read bundle instance %s for the source of parameter %s", installation, name)
+			}
+			if err == nil {
+				sourceClaim = &existing
+			}
+			installations[installation] = sourceClaim
+		}
+
+		if sourceClaim == nil {
+			if d.Debug {
+				fmt.Fprintf(d.Err, "bundle instance %s does not exist, using the default value for parameter %s\n", installation, name)
+			}
+			continue
+		}
+
+		value, ok := sourceClaim.Outputs[source.Output]
+		if !ok {
+			if d.Debug {
+				fmt.Fprintf(d.Err, "bundle instance %s does not have the output %s, using the default value for parameter %s\n", installation, source.Output, name)
+			}
+			continue
+		}
+		params[name] = fmt.Sprintf("%v", value)
+	}
+
+	return params, nil
+}
+
 // loadParameters accepts a set of string overrides and combines that with the default parameters to create
-// a full set of parameters.
-func (d *Runtime) loadParameters(claim *claim.Claim, rawOverrides map[string]string, action string) (map[string]interface{}, error) {
-	overrides := make(map[string]interface{}, len(rawOverrides))
+// a full set of parameters. Each value is converted to the type of its parameter and validated against the
+// parameter's definition, and every violation is reported at once before the bundle is executed.
+// The values of file parameters specified by the user are paths to the files, while the values resolved
+// from parameter sources are used as-is.
+func (d *Runtime) loadParameters(claim *claim.Claim, rawOverrides map[string]string, sourced map[string]string, action string) (map[string]interface{}, error) {
+	overrides := make(map[string]interface{}, len(rawOverrides)+len(sourced))
 	bun := claim.Bundle
 
-	for key, rawValue := range rawOverrides {
+	// Sort the parameters so that violations are reported in a consistent order
+	keys := make([]string, 0, len(rawOverrides)+len(sourced))
+	for key := range rawOverrides {
+		keys = append(keys, key)
+	}
+	for key := range sourced {
+		if _, ok := rawOverrides[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var violations []string
+	for _, key := range keys {
+		rawValue, specified := rawOverrides[key]
+		if !specified {
+			rawValue = sourced[key]
+		}
 		param, ok := bun.Parameters[key]
 		if !ok {
-			return nil, fmt.Errorf("parameter %s not defined in bundle", key)
+			violations = append(violations, fmt.Sprintf("parameter %s not defined in bundle", key))
+			continue
 		}
 
 		def, ok := bun.Definitions[param.Definition]
@@ -27,14 +114,28 @@ func (d *Runtime) loadParameters(claim *claim.Claim, rawOverrides map[string]str
 			return nil, fmt.Errorf("definition %s not defined in bundle", param.Definition)
 		}
 
-		unconverted, err := d.getUnconvertedValueFromRaw(def, key, rawValue)
+		var err error
+		unconverted := rawValue
+		if specified {
+			unconverted, err = d.getUnconvertedValueFromRaw(def, key, rawValue)
+			if err != nil {
+				violations = append(violations, err.Error())
+				continue
+			}
+		}
+
+		value, err := parameters.ConvertValue(def, unconverted)
 		if err != nil {
-			return nil, err
+			violations = append(violations, fmt.Sprintf("unable to convert parameter's %s value %s to the destination parameter type %s: %s", key, rawValue, def.Type, err))
+			continue
 		}
 
-		value, err := def.ConvertValue(unconverted)
+		valErrs, err := def.Validate(value)
 		if err != nil {
-			return nil, errors.Wrapf(err, "unable to convert parameter's %s value %s to the destination parameter type %s", key, rawValue, def.Type)
+			return nil, errors.Wrapf(err, "unable to validate parameter %s", key)
+		}
+		for _, valErr := range valErrs {
+			violations = append(violations, fmt.Sprintf("invalid value for parameter %s: %s", key, valErr.Error))
 		}
 
 		overrides[key] = value
@@ -46,6 +147,32 @@ func (d *Runtime) loadParameters(claim *claim.Claim, rawOverrides map[string]str
 		}
 	}
 
+	// Required parameters that apply to the action must be specified
+	names := make([]string, 0, len(bun.Parameters))
+	for name := range bun.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		param := bun.Parameters[name]
+		if !param.Required || !param.AppliesTo(action) {
+			continue
+		}
+		if _, exists := rawOverrides[name]; exists {
+			continue
+		}
+		if _, exists := sourced[name]; !exists {
+			violations = append(violations, fmt.Sprintf("parameter %s is required", name))
+		}
+	}
+
+	if len(violations) == 1 {
+		return nil, errors.New(violations[0])
+	}
+	if len(violations) > 1 {
+		return nil, errors.Errorf("found %d problems:\n  * %s", len(violations), strings.Join(violations, "\n  * "))
+	}
+
 	// rawOverrides (meaning, user-supplied overrides at time of action invocation)
 	// may supply no entry for a parameter designated as required *but* that does not apply to this action.
 	//
@@ -58,7 +185,7 @@ func (d *Runtime) loadParameters(claim *claim.Claim, rawOverrides map[string]str
 			return nil, fmt.Errorf("parameter definition %s not defined in bundle", param.Definition)
 		}
 		if param.Required {
-			if _, exists := rawOverrides[name]; !exists {
+			if _, exists := overrides[name]; !exists {
 				if !param.AppliesTo(action) {
 					// First defer to a pre-existing value in the claim
 					if claim.Parameters[name] != nil {
@@ -79,15 +206,16 @@ func (d *Runtime) loadParameters(claim *claim.Claim, rawOverrides map[string]str
 }
 
 func (d *Runtime) getUnconvertedValueFromRaw(def *definition.Schema, key, rawValue string) (string, error) {
-	// the parameter value (via rawValue) may represent a file on the local filesystem
+	// the parameter value (via rawValue) is the path to a file on the local filesystem
 	if def.Type == "string" && def.ContentEncoding == "base64" {
-		if _, err := d.FileSystem.Stat(rawValue); err == nil {
-			bytes, err := d.FileSystem.ReadFile(rawValue)
-			if err != nil {
-				return "", errors.Wrapf(err, "unable to read file parameter %s", key)
-			}
-			return base64.StdEncoding.EncodeToString(bytes), nil
+		if _, err := d.FileSystem.Stat(rawValue); err != nil {
+			return "", errors.Errorf("file %s for parameter %s does not exist", rawValue, key)
+		}
+		bytes, err := d.FileSystem.ReadFile(rawValue)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to read file parameter %s", key)
 		}
+		return base64.StdEncoding.EncodeToString(bytes), nil
 	}
 	return rawValue, nil
 }
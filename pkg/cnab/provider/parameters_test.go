@@ -5,9 +5,11 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"get.porter.sh/porter/pkg/cnab/extensions"
 	"github.com/cnabio/cnab-go/bundle"
 	"github.com/cnabio/cnab-go/bundle/definition"
 	"github.com/cnabio/cnab-go/claim"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,7 +27,7 @@ func Test_loadParameters_paramNotDefined(t *testing.T) {
 		"foo": "bar",
 	}
 
-	_, err = d.loadParameters(claim, overrides, "action")
+	_, err = d.loadParameters(claim, overrides, nil, "action")
 	require.EqualError(t, err, "parameter foo not defined in bundle")
 }
 
@@ -47,7 +49,7 @@ func Test_loadParameters_definitionNotDefined(t *testing.T) {
 		"foo": "bar",
 	}
 
-	_, err = d.loadParameters(claim, overrides, "action")
+	_, err = d.loadParameters(claim, overrides, nil, "action")
 	require.EqualError(t, err, "definition foo not defined in bundle")
 }
 
@@ -105,7 +107,7 @@ func Test_loadParameters_applyToClaimDefaults(t *testing.T) {
 		"true": "false",
 	}
 
-	params, err := d.loadParameters(claim, overrides, "action")
+	params, err := d.loadParameters(claim, overrides, nil, "action")
 	require.NoError(t, err)
 
 	require.Equal(t, "FOO", params["foo"], "expected param 'foo' to be updated")
@@ -140,7 +142,7 @@ func Test_loadParameters_applyToBundleDefaults(t *testing.T) {
 
 	overrides := map[string]string{}
 
-	params, err := d.loadParameters(claim, overrides, "action")
+	params, err := d.loadParameters(claim, overrides, nil, "action")
 	require.NoError(t, err)
 
 	require.Equal(t, "foo-default", params["foo"], "expected param 'foo' to be the bundle default")
@@ -175,7 +177,7 @@ func Test_loadParameters_requiredButDoesNotApply(t *testing.T) {
 
 	overrides := map[string]string{}
 
-	params, err := d.loadParameters(claim, overrides, "action")
+	params, err := d.loadParameters(claim, overrides, nil, "action")
 	require.NoError(t, err)
 
 	require.Equal(t, "foo-claim-value", params["foo"], "expected param 'foo' to be the previous value from the claim")
@@ -223,7 +225,7 @@ func Test_loadParameters_zeroValues(t *testing.T) {
 			claim.Parameters = map[string]interface{}{}
 			overrides := map[string]string{}
 
-			params, err := d.loadParameters(claim, overrides, "action")
+			params, err := d.loadParameters(claim, overrides, nil, "action")
 			require.NoError(t, err)
 
 			require.Equal(t, tc.expectedVal, params["foo"], "unexpected value for param 'foo")
@@ -261,7 +263,7 @@ func Test_loadParameters_fileParameter(t *testing.T) {
 		"foo": "/path/to/file",
 	}
 
-	params, err := d.loadParameters(claim, overrides, "action")
+	params, err := d.loadParameters(claim, overrides, nil, "action")
 	require.NoError(t, err)
 
 	require.Equal(t, "SGVsbG8gV29ybGQh", params["foo"], "expected param 'foo' to be the base64-encoded file contents")
@@ -299,13 +301,13 @@ func Test_Paramapalooza(t *testing.T) {
 				// be provided if applicable to an action.
 				// Otherwise, it errors out and does not look up/use default in this case.
 				{"required, not provided, default exists, applies to action",
-					true, false, true, true, nil, "invalid parameters: parameter \"my-param\" is required",
+					true, false, true, true, nil, "invalid parameters: parameter my-param is required",
 				},
 				{"required, not provided, default exists, does not apply to action",
 					true, false, true, false, "my-param-default", "",
 				},
 				{"required, not provided, default does not exist, applies to action",
-					true, false, false, true, nil, "invalid parameters: parameter \"my-param\" is required",
+					true, false, false, true, nil, "invalid parameters: parameter my-param is required",
 				},
 				{"required, not provided, default does not exist, does not apply to action",
 					true, false, false, false, "", "",
@@ -444,3 +446,224 @@ func Test_Paramapalooza(t *testing.T) {
 		})
 	}
 }
+
+func Test_loadParameters_typedValues(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	c, err := claim.New("test")
+	require.NoError(t, err)
+
+	c.Bundle = &bundle.Bundle{
+		Definitions: definition.Definitions{
+			"object":  &definition.Schema{Type: "object"},
+			"array":   &definition.Schema{Type: "array"},
+			"number":  &definition.Schema{Type: "number"},
+			"integer": &definition.Schema{Type: "integer"},
+			"boolean": &definition.Schema{Type: "boolean"},
+		},
+		Parameters: map[string]bundle.Parameter{
+			"config":   {Definition: "object"},
+			"tags":     {Definition: "array"},
+			"ratio":    {Definition: "number"},
+			"replicas": {Definition: "integer"},
+			"debug":    {Definition: "boolean"},
+		},
+	}
+
+	overrides := map[string]string{
+		"config":   `{"logging":{"level":"info"}}`,
+		"tags":     `["web","prod"]`,
+		"ratio":    "0.5",
+		"replicas": "3",
+		"debug":    "true",
+	}
+
+	params, err := d.loadParameters(c, overrides, nil, "install")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"logging": map[string]interface{}{"level": "info"}}, params["config"])
+	assert.Equal(t, []interface{}{"web", "prod"}, params["tags"])
+	assert.Equal(t, 0.5, params["ratio"])
+	assert.Equal(t, 3, params["replicas"])
+	assert.Equal(t, true, params["debug"])
+}
+
+func Test_loadParameters_typeErrors(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	c, err := claim.New("test")
+	require.NoError(t, err)
+
+	c.Bundle = &bundle.Bundle{
+		Definitions: definition.Definitions{
+			"object":  &definition.Schema{Type: "object"},
+			"integer": &definition.Schema{Type: "integer"},
+			"boolean": &definition.Schema{Type: "boolean"},
+		},
+		Parameters: map[string]bundle.Parameter{
+			"config":   {Definition: "object"},
+			"replicas": {Definition: "integer"},
+			"debug":    {Definition: "boolean"},
+		},
+	}
+
+	overrides := map[string]string{
+		"config":   `["not", "an", "object"]`,
+		"replicas": "three",
+		"debug":    "true",
+	}
+
+	_, err = d.loadParameters(c, overrides, nil, "install")
+	require.EqualError(t, err, `found 2 problems:
+  * unable to convert parameter's config value ["not", "an", "object"] to the destination parameter type object: "[\"not\", \"an\", \"object\"]" is not a valid JSON object
+  * unable to convert parameter's replicas value three to the destination parameter type integer: "three" is not a valid integer`)
+}
+
+func Test_loadParameters_schemaViolations(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	c, err := claim.New("test")
+	require.NoError(t, err)
+
+	min, maxLength := 1, 5
+	c.Bundle = &bundle.Bundle{
+		Definitions: definition.Definitions{
+			"region":   &definition.Schema{Type: "string", Enum: []interface{}{"eastus", "westus"}},
+			"name":     &definition.Schema{Type: "string", MaxLength: &maxLength},
+			"replicas": &definition.Schema{Type: "integer", Minimum: &min},
+			"file":     &definition.Schema{Type: "string", ContentEncoding: "base64"},
+			"password": &definition.Schema{Type: "string"},
+		},
+		Parameters: map[string]bundle.Parameter{
+			"region":     {Definition: "region"},
+			"name":       {Definition: "name"},
+			"replicas":   {Definition: "replicas"},
+			"kubeconfig": {Definition: "file"},
+			"password":   {Definition: "password", Required: true},
+			"tag":        {Definition: "name", Required: true, ApplyTo: []string{"upgrade"}},
+		},
+	}
+
+	overrides := map[string]string{
+		"region":     "northpole",
+		"name":       "My App",
+		"replicas":   "0",
+		"kubeconfig": "/missing/kubeconfig",
+		"color":      "blue",
+	}
+
+	_, err = d.loadParameters(c, overrides, nil, "install")
+	require.EqualError(t, err, `found 6 problems:
+  * parameter color not defined in bundle
+  * file /missing/kubeconfig for parameter kubeconfig does not exist
+  * invalid value for parameter name: max length of 5 characters exceeded: My App
+  * invalid value for parameter region: should be one of ["eastus", "westus"]
+  * invalid value for parameter replicas: must be greater than or equal to 1.000000
+  * parameter password is required`)
+}
+
+func Test_resolveParameterSources(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	previous, err := claim.New("wordpress")
+	require.NoError(t, err)
+	previous.Bundle = &bundle.Bundle{Name: "wordpress"}
+	previous.Outputs = map[string]interface{}{
+		"admin-password": "s3cr3t",
+		"port":           8080,
+	}
+	require.NoError(t, d.claims.Save(*previous))
+
+	mysql, err := claim.New("mysql")
+	require.NoError(t, err)
+	mysql.Bundle = &bundle.Bundle{Name: "mysql"}
+	mysql.Outputs = map[string]interface{}{
+		"host": "mysql.example.com",
+	}
+	require.NoError(t, d.claims.Save(*mysql))
+
+	c, err := claim.New("wordpress")
+	require.NoError(t, err)
+	c.Bundle = &bundle.Bundle{
+		Name: "wordpress",
+		Custom: map[string]interface{}{
+			extensions.ParameterSourcesKey: extensions.ParameterSources{
+				"admin-password": {Output: "admin-password"},
+				"port":           {Output: "port"},
+				"mysql-host":     {Installation: "mysql", Output: "host"},
+				"mysql-port":     {Installation: "mysql", Output: "port"},
+				"redis-host":     {Installation: "redis", Output: "host"},
+				"region":         {Output: "region"},
+			},
+		},
+	}
+
+	overrides := map[string]string{
+		"port":   "9090",
+		"region": "westus",
+	}
+
+	params, err := d.resolveParameterSources(c, overrides)
+	require.NoError(t, err)
+
+	wantParams := map[string]string{
+		"admin-password": "s3cr3t",
+		"mysql-host":     "mysql.example.com",
+	}
+	assert.Equal(t, wantParams, params, "specified values should take precedence, and missing outputs should be skipped")
+	assert.Equal(t, map[string]string{"port": "9090", "region": "westus"}, overrides, "the overrides should not be modified")
+}
+
+func Test_resolveParameterSources_NoSources(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	c, err := claim.New("wordpress")
+	require.NoError(t, err)
+	c.Bundle = &bundle.Bundle{Name: "wordpress"}
+
+	overrides := map[string]string{"region": "westus"}
+	params, err := d.resolveParameterSources(c, overrides)
+	require.NoError(t, err)
+	assert.Empty(t, params)
+}
+
+func Test_loadParameters_fileParameterFromSource(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	mysql, err := claim.New("wordpress-mysql")
+	require.NoError(t, err)
+	mysql.Bundle = &bundle.Bundle{Name: "mysql"}
+	mysql.Outputs = map[string]interface{}{
+		"tls-cert": "Y2VydA==",
+	}
+	require.NoError(t, d.claims.Save(*mysql))
+
+	c, err := claim.New("wordpress")
+	require.NoError(t, err)
+	c.Bundle = &bundle.Bundle{
+		Name: "wordpress",
+		Definitions: definition.Definitions{
+			"file": &definition.Schema{Type: "string", ContentEncoding: "base64"},
+		},
+		Parameters: map[string]bundle.Parameter{
+			"tls-cert":   {Definition: "file", Required: true},
+			"kubeconfig": {Definition: "file"},
+		},
+		Custom: map[string]interface{}{
+			extensions.ParameterSourcesKey: extensions.ParameterSources{
+				"tls-cert": {Installation: "wordpress-mysql", Output: "tls-cert"},
+			},
+		},
+	}
+
+	overrides := map[string]string{"kubeconfig": "/missing/kubeconfig"}
+	sourced, err := d.resolveParameterSources(c, overrides)
+	require.NoError(t, err)
+
+	_, err = d.loadParameters(c, overrides, sourced, "install")
+	require.EqualError(t, err, "file /missing/kubeconfig for parameter kubeconfig does not exist", "the value of a file parameter specified by the user should be a path")
+
+	params, err := d.loadParameters(c, map[string]string{}, sourced, "install")
+	require.NoError(t, err)
+	assert.Equal(t, "Y2VydA==", params["tls-cert"], "the value of a file parameter from a dependency output should be used as-is")
+}
@@ -0,0 +1,101 @@
+package cnabprovider
+
+import (
+	"fmt"
+
+	cnabaction "github.com/cnabio/cnab-go/action"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/driver"
+	"github.com/cnabio/cnab-go/driver/docker"
+	"github.com/pkg/errors"
+)
+
+// StatusAction is the custom action that a bundle defines to report the live status of an
+// installation. It must be read-only: it does not modify resources and is not stateless.
+const StatusAction = "status"
+
+// Status executes the status action of the bundle against an installation, with the
+// parameters of the installation, and returns the outputs of the action. The status action
+// does not modify the installation, so the claim is not updated and the run is not recorded
+// in the history. The output of the action is written to stderr so that it does not mix
+// with the report of the status.
+func (d *Runtime) Status(args ActionArguments) (map[string]interface{}, error) {
+	c, err := d.claims.Read(args.Claim)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load claim %s", args.Claim)
+	}
+
+	if c.Bundle == nil {
+		return nil, errors.Errorf("the bundle of bundle instance %s was not recorded", c.Name)
+	}
+	action, ok := c.Bundle.Actions[StatusAction]
+	if !ok {
+		return nil, errors.Errorf("bundle %s does not define a %s action", c.Bundle.Name, StatusAction)
+	}
+	if action.Modifies || action.Stateless {
+		return nil, errors.Errorf("the %s action of bundle %s must not modify resources or be stateless", StatusAction, c.Bundle.Name)
+	}
+
+	dvr, err := d.newDriver(args.Driver, c.Name, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to instantiate driver")
+	}
+	// The outputs of actions that don't modify resources aren't set on the claim, keep them from the driver
+	statusDriver := &outputsDriver{Driver: dvr}
+
+	creds, err := d.loadCredentials(c.Bundle, args.CredentialIdentifiers)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load credentials")
+	}
+
+	if d.Debug {
+		fmt.Fprintf(d.Err, "checking the status of bundle instance %s with bundle %s\n", c.Name, c.Bundle.Name)
+	}
+
+	// The docker driver writes the output of the container directly to stdout and stderr
+	if dockerDriver, ok := dvr.(*docker.Driver); ok {
+		dockerDriver.SetContainerOut(d.Err)
+		dockerDriver.SetContainerErr(d.Err)
+	}
+	writeToErr := func(op *driver.Operation) error {
+		op.Out = d.Err
+		return nil
+	}
+
+	i := cnabaction.RunCustom{
+		Action: StatusAction,
+		Driver: statusDriver,
+	}
+	err = i.Run(&c, creds, append(d.ApplyConfig(args), writeToErr)...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run the %s action", StatusAction)
+	}
+
+	return statusOutputs(c.Bundle, statusDriver.result), nil
+}
+
+// statusOutputs returns the outputs of the bundle that the status action generated, by name.
+func statusOutputs(bun *bundle.Bundle, result driver.OperationResult) map[string]interface{} {
+	outputs := make(map[string]interface{})
+	for name, output := range bun.Outputs {
+		if !output.AppliesTo(StatusAction) {
+			continue
+		}
+		if value, ok := result.Outputs[output.Path]; ok {
+			outputs[name] = value
+		}
+	}
+	return outputs
+}
+
+// outputsDriver keeps the result of the operation that it runs.
+type outputsDriver struct {
+	driver.Driver
+	result driver.OperationResult
+}
+
+func (d *outputsDriver) Run(op *driver.Operation) (driver.OperationResult, error) {
+	result, err := d.Driver.Run(op)
+	d.result = result
+	return result, err
+}
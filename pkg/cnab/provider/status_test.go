@@ -0,0 +1,74 @@
+package cnabprovider
+
+import (
+	"testing"
+
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntime_Status(t *testing.T) {
+	d := NewTestRuntime(t)
+
+	args := ActionArguments{
+		Claim:      "mybuns",
+		BundlePath: "testdata/bundle.json",
+		Insecure:   true,
+		Driver:     "debug",
+	}
+	require.NoError(t, d.Install(args))
+
+	_, err := d.Status(ActionArguments{Claim: "mybuns", Driver: "debug"})
+	require.EqualError(t, err, "bundle mybuns does not define a status action")
+
+	c, err := d.claims.Read("mybuns")
+	require.NoError(t, err)
+	c.Bundle.Actions[StatusAction] = bundle.Action{Modifies: true}
+	require.NoError(t, d.claims.Save(c))
+
+	_, err = d.Status(ActionArguments{Claim: "mybuns", Driver: "debug"})
+	require.EqualError(t, err, "the status action of bundle mybuns must not modify resources or be stateless")
+
+	c.Bundle.Actions[StatusAction] = bundle.Action{}
+	require.NoError(t, d.claims.Save(c))
+
+	outputs, err := d.Status(ActionArguments{Claim: "mybuns", Driver: "debug"})
+	require.NoError(t, err)
+	assert.Empty(t, outputs, "the debug driver does not generate outputs")
+
+	updated, err := d.claims.Read("mybuns")
+	require.NoError(t, err)
+	assert.Equal(t, c.Revision, updated.Revision, "the status action should not update the claim")
+
+	runs, err := d.claims.ReadHistory("mybuns")
+	require.NoError(t, err)
+	assert.Len(t, runs, 1, "the status action should not be recorded in the history")
+}
+
+func TestStatusOutputs(t *testing.T) {
+	bun := &bundle.Bundle{
+		Outputs: map[string]bundle.Output{
+			"endpoint": {Definition: "string", Path: "/cnab/app/outputs/endpoint"},
+			"replicas": {Definition: "integer", Path: "/cnab/app/outputs/replicas", ApplyTo: []string{"install", "status"}},
+			"password": {Definition: "string", Path: "/cnab/app/outputs/password", ApplyTo: []string{"install"}},
+			"healthy":  {Definition: "boolean", Path: "/cnab/app/outputs/healthy", ApplyTo: []string{"status"}},
+		},
+	}
+	result := driver.OperationResult{
+		Outputs: map[string]string{
+			"/cnab/app/outputs/endpoint": "10.0.0.1",
+			"/cnab/app/outputs/replicas": "3",
+			"/cnab/app/outputs/password": "secret",
+		},
+	}
+
+	outputs := statusOutputs(bun, result)
+
+	want := map[string]interface{}{
+		"endpoint": "10.0.0.1",
+		"replicas": "3",
+	}
+	assert.Equal(t, want, outputs, "only the outputs of the status action that were generated should be returned")
+}
@@ -3,9 +3,11 @@ package cnabprovider
 import (
 	"fmt"
 
+	"get.porter.sh/porter/pkg/claims"
 	"get.porter.sh/porter/pkg/manifest"
 	"github.com/cnabio/cnab-go/action"
 	"github.com/cnabio/cnab-go/claim"
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 )
 
@@ -27,7 +29,12 @@ func (d *Runtime) Uninstall(args ActionArguments) error {
 		}
 	}
 
-	c.Parameters, err = d.loadParameters(&c, args.Params, string(manifest.ActionUninstall))
+	sourced, err := d.resolveParameterSources(&c, args.Params)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve parameter sources")
+	}
+
+	c.Parameters, err = d.loadParameters(&c, args.Params, sourced, string(manifest.ActionUninstall))
 	if err != nil {
 		return errors.Wrap(err, "invalid parameters")
 	}
@@ -59,9 +66,35 @@ func (d *Runtime) Uninstall(args ActionArguments) error {
 		fmt.Fprintf(d.Err, "uninstalling bundle %s (%s) as %s\n\tparams: %v\n\tcreds: %v\n", c.Bundle.Name, args.BundlePath, c.Name, paramKeys, credKeys)
 	}
 
-	err = i.Run(&c, creds, d.ApplyConfig(args)...)
+	var result *multierror.Error
+	revision := c.Revision
+	logs, captureLogs := d.captureLogs(driver, &c, creds)
+	err = i.Run(&c, creds, append(d.ApplyConfig(args), captureLogs)...)
+	if err != nil {
+		result = multierror.Append(result, errors.Wrap(err, "failed to uninstall the bundle"))
+	}
+
+	err = claims.RecordActionProgress(&c)
 	if err != nil {
-		return errors.Wrap(err, "failed to uninstall the bundle")
+		result = multierror.Append(result, err)
+	}
+
+	// Write out the claim when the uninstall fails, so that the failure is recorded
+	if c.Result.Status == claim.StatusFailure {
+		err = d.claims.Save(c)
+		if err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to record the uninstall for the bundle"))
+		}
+	}
+
+	// The history of the installation is kept after it is uninstalled
+	err = d.recordRun(c, args, revision, logs.String())
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	if result.ErrorOrNil() != nil {
+		return result
 	}
 
 	err = d.claims.Delete(args.Claim)
@@ -3,6 +3,7 @@ package cnabprovider
 import (
 	"fmt"
 
+	"get.porter.sh/porter/pkg/claims"
 	"get.porter.sh/porter/pkg/manifest"
 	"github.com/cnabio/cnab-go/action"
 	"github.com/hashicorp/go-multierror"
@@ -23,7 +24,17 @@ func (d *Runtime) Upgrade(args ActionArguments) error {
 		}
 	}
 
-	c.Parameters, err = d.loadParameters(&c, args.Params, string(manifest.ActionUpgrade))
+	err = claims.SetSharedDependencies(&c, args.SharedDependencies)
+	if err != nil {
+		return err
+	}
+
+	sourced, err := d.resolveParameterSources(&c, args.Params)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve parameter sources")
+	}
+
+	c.Parameters, err = d.loadParameters(&c, args.Params, sourced, string(manifest.ActionUpgrade))
 	if err != nil {
 		return errors.Wrap(err, "invalid parameters")
 	}
@@ -57,16 +68,28 @@ func (d *Runtime) Upgrade(args ActionArguments) error {
 
 	var result *multierror.Error
 	// Upgrade and capture error
-	err = i.Run(&c, creds, d.ApplyConfig(args)...)
+	revision := c.Revision
+	logs, captureLogs := d.captureLogs(driver, &c, creds)
+	err = i.Run(&c, creds, append(d.ApplyConfig(args), captureLogs)...)
 	if err != nil {
 		result = multierror.Append(result, errors.Wrap(err, "failed to upgrade the bundle"))
 	}
 
+	err = claims.RecordActionProgress(&c)
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	// ALWAYS write out a claim, even if the upgrade fails
 	err = d.claims.Save(c)
 	if err != nil {
 		result = multierror.Append(result, errors.Wrap(err, "failed to record the upgrade for the bundle"))
 	}
 
+	err = d.recordRun(c, args, revision, logs.String())
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	return result.ErrorOrNil()
 }
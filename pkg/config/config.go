@@ -13,6 +13,9 @@ const (
 	// Name is the file name of the porter configuration file.
 	Name = "porter.yaml"
 
+	// LockName is the file name of the porter dependency lock file, stored next to the porter configuration file.
+	LockName = "porter.lock"
+
 	// EnvHOME is the name of the environment variable containing the porter home directory path.
 	EnvHOME = "PORTER_HOME"
 
@@ -28,6 +31,9 @@ const (
 	// EnvDEBUG is a custom porter parameter that signals that --debug flag has been passed through from the client to the runtime.
 	EnvDEBUG = "PORTER_DEBUG"
 
+	// EnvResume signals to the runtime that it should skip the steps that completed in the last failed run of the action.
+	EnvResume = "PORTER_RESUME"
+
 	CustomBundleKey = "sh.porter"
 
 	// BundleOutputsDir is the directory where outputs are expected to be placed
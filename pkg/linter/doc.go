@@ -0,0 +1 @@
+package linter // import "get.porter.sh/porter/pkg/linter"
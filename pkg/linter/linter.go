@@ -0,0 +1,150 @@
+package linter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"get.porter.sh/porter/pkg/manifest"
+	"github.com/cnabio/cnab-go/bundle"
+)
+
+// Level is the severity of a problem found by a rule.
+type Level string
+
+const (
+	// LevelError is a problem that should be fixed before the bundle is published.
+	LevelError Level = "error"
+
+	// LevelWarning is a problem that should be reviewed, but may be intentional.
+	LevelWarning Level = "warning"
+)
+
+// Location is where a problem was found.
+type Location struct {
+	// File is the manifest, or an included file, where the problem was found.
+	File string `json:"file" yaml:"file"`
+
+	// Line is the line in the file where the problem was found, starting at 1.
+	// Line is 0 when the line could not be determined.
+	Line int `json:"line,omitempty" yaml:"line,omitempty"`
+}
+
+func (l Location) String() string {
+	if l.Line == 0 {
+		return l.File
+	}
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// Result is a problem found by a rule.
+type Result struct {
+	Level    Level    `json:"level" yaml:"level"`
+	Code     string   `json:"code" yaml:"code"`
+	Title    string   `json:"title" yaml:"title"`
+	Message  string   `json:"message" yaml:"message"`
+	Location Location `json:"location" yaml:"location"`
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s: %s %s: %s", r.Location, r.Level, r.Code, r.Message)
+}
+
+// Results are the problems found when linting a bundle.
+type Results []Result
+
+// HasError determines if any of the results are errors.
+func (r Results) HasError() bool {
+	for _, result := range r {
+		if result.Level == LevelError {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Results) String() string {
+	lines := make([]string, len(r))
+	for i, result := range r {
+		lines[i] = result.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// File is the contents of the manifest, or a file that it includes.
+type File struct {
+	Path string
+	Data []byte
+}
+
+// Input is the bundle that is linted.
+type Input struct {
+	// Manifest is the porter manifest, with any included files merged into it.
+	Manifest *manifest.Manifest
+
+	// Bundle is the bundle generated from the manifest.
+	Bundle *bundle.Bundle
+
+	// Files are the manifest followed by the files that it includes, used to find
+	// where a problem is in the files. Use AddFile so that each file is only parsed once.
+	Files []File
+
+	// documents are the parsed Files, in the same order.
+	documents []document
+}
+
+// AddFile adds a file to the input and parses it, so that the rules can find
+// where a problem is in the file.
+func (in *Input) AddFile(path string, data []byte) {
+	f := File{Path: path, Data: data}
+	in.documents = append(in.getDocuments(), parseDocument(f))
+	in.Files = append(in.Files, f)
+}
+
+// Rule checks a bundle for a problem.
+type Rule interface {
+	// Code uniquely identifies the rule, for example porter-101.
+	Code() string
+
+	// Title briefly describes the problem found by the rule.
+	Title() string
+
+	// Lint checks the bundle and returns the problems found.
+	Lint(input *Input) Results
+}
+
+// Linter checks a bundle against a set of rules.
+type Linter struct {
+	Rules []Rule
+}
+
+// New creates a linter with the default rules.
+func New() *Linter {
+	return &Linter{Rules: DefaultRules()}
+}
+
+// Lint checks the bundle against each rule, and returns the problems found
+// sorted by where they were found.
+func (l *Linter) Lint(input *Input) Results {
+	results := Results{}
+	for _, rule := range l.Rules {
+		results = append(results, rule.Lint(input)...)
+	}
+
+	files := make(map[string]int, len(input.Files))
+	for i, f := range input.Files {
+		files[f.Path] = i
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i].Location, results[j].Location
+		if a.File != b.File {
+			return files[a.File] < files[b.File]
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return results[i].Code < results[j].Code
+	})
+
+	return results
+}
@@ -0,0 +1,112 @@
+package linter
+
+import (
+	"testing"
+
+	configadapter "get.porter.sh/porter/pkg/cnab/config-adapter"
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestInput(t *testing.T, cxt *context.TestContext, manifestPath string) *Input {
+	m, err := manifest.ReadManifest(cxt.Context, manifestPath)
+	require.NoError(t, err)
+
+	input := &Input{Manifest: m}
+	for _, file := range m.Files {
+		input.AddFile(file.Location, file.Data)
+	}
+	input.Bundle = configadapter.NewManifestConverter(cxt.Context, m, nil, nil).ToBundle()
+	return input
+}
+
+func TestLinter_Lint(t *testing.T) {
+	cxt := context.NewTestContext(t)
+	cxt.AddTestDirectory("testdata", ".")
+	input := loadTestInput(t, cxt, "porter.yaml")
+
+	results := New().Lint(input)
+
+	want := []string{
+		"porter.yaml:3: warning porter-104: the bundle tag getporter/lint does not specify a version, for example docker.io/getporter/lint:v0.1.0",
+		"porter.yaml:15: warning porter-101: parameter unused is not used by any step. Reference it in a step with {{ bundle.parameters.unused }}, or set its env or path when a script reads it",
+		`porter.yaml:34: error porter-103: step "Print the password" in the install action prints the sensitive parameter password`,
+		`actions.yaml:2: error porter-102: step "Status" in the status action references the output missing, which is not defined by the bundle or any step`,
+		"actions.yaml:9: warning porter-106: the status action should set modifies and stateless to false so that porter instances status can use it to detect drift",
+		"actions.yaml:13: warning porter-105: custom action backup does not have a description. Describe it in customActions so that users know what it does",
+	}
+	got := make([]string, len(results))
+	for i, result := range results {
+		got[i] = result.String()
+	}
+	assert.Equal(t, want, got)
+	assert.True(t, results.HasError())
+}
+
+func TestLinter_Lint_NoProblems(t *testing.T) {
+	cxt := context.NewTestContext(t)
+	cxt.AddTestFile("../manifest/testdata/simple.porter.yaml", "porter.yaml")
+	input := loadTestInput(t, cxt, "porter.yaml")
+
+	results := New().Lint(input)
+	assert.Empty(t, results)
+	assert.False(t, results.HasError())
+}
+
+type testRule struct{}
+
+func (testRule) Code() string  { return "test-001" }
+func (testRule) Title() string { return "Test rule" }
+func (r testRule) Lint(input *Input) Results {
+	return Results{{Level: LevelWarning, Code: r.Code(), Title: r.Title(), Message: input.Manifest.Name, Location: input.locateKey("name")}}
+}
+
+func TestLinter_CustomRules(t *testing.T) {
+	cxt := context.NewTestContext(t)
+	cxt.AddTestDirectory("testdata", ".")
+	input := loadTestInput(t, cxt, "porter.yaml")
+
+	l := &Linter{Rules: []Rule{testRule{}}}
+	results := l.Lint(input)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "porter.yaml:1: warning test-001: lint", results[0].String())
+	assert.False(t, results.HasError())
+}
+
+func TestUnversionedTagRule(t *testing.T) {
+	testcases := []struct {
+		tag  string
+		want bool
+	}{
+		{"getporter/lint", true},
+		{"getporter/lint:latest", true},
+		{"getporter/lint:v0.1.0", false},
+		{"getporter/lint@sha256:6b5a28ccbb76f12ce771a23757880c6083234255c5ba191fca1c5db1f71c1687", false},
+		{"", false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.tag, func(t *testing.T) {
+			input := &Input{Manifest: &manifest.Manifest{BundleTag: tc.tag, Version: "0.1.0"}}
+			results := unversionedTagRule{}.Lint(input)
+			assert.Equal(t, tc.want, len(results) > 0)
+		})
+	}
+}
+
+func TestInput_AddFile(t *testing.T) {
+	input := &Input{}
+	input.AddFile("porter.yaml", []byte("name: mybuns\n"))
+	input.Files = append(input.Files, File{Path: "actions.yaml", Data: []byte("install: []\n")})
+	input.AddFile("outputs.yaml", []byte("outputs: []\n"))
+
+	docs := input.getDocuments()
+	require.Len(t, docs, 3, "each file should be parsed once")
+	assert.Equal(t, "mybuns", docs[0].values["name"])
+	assert.Equal(t, "actions.yaml", docs[1].Path)
+	assert.Contains(t, docs[2].values, "outputs")
+	assert.Equal(t, Location{File: "outputs.yaml", Line: 1}, input.locateKey("outputs"))
+}
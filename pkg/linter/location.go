@@ -0,0 +1,124 @@
+package linter
+
+import (
+	"strconv"
+
+	"get.porter.sh/porter/pkg/manifest"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// document is an unmarshaled file, and its YAML nodes which are used to find the line
+// where a value is defined.
+type document struct {
+	File
+	values map[string]interface{}
+	node   *yamlv3.Node
+}
+
+// getDocuments returns the parsed files, parsing any files that were set directly on Files.
+func (in *Input) getDocuments() []document {
+	for i := len(in.documents); i < len(in.Files); i++ {
+		in.documents = append(in.documents, parseDocument(in.Files[i]))
+	}
+	return in.documents[:len(in.Files)]
+}
+
+func parseDocument(f File) document {
+	values := make(map[string]interface{})
+	// A file that can't be unmarshaled is reported when the manifest is loaded, just don't search it
+	_ = yaml.Unmarshal(f.Data, &values)
+	node := &yamlv3.Node{}
+	_ = yamlv3.Unmarshal(f.Data, node)
+	return document{File: f, values: values, node: node}
+}
+
+// defaultLocation is used when a problem isn't found in any of the files.
+func (in *Input) defaultLocation() Location {
+	if len(in.Files) > 0 {
+		return Location{File: in.Files[0].Path}
+	}
+	if in.Manifest != nil {
+		return Location{File: in.Manifest.ManifestPath}
+	}
+	return Location{}
+}
+
+// locateKey returns the location of the first file that defines the key, for example tag.
+func (in *Input) locateKey(key string) Location {
+	for _, doc := range in.getDocuments() {
+		if _, ok := doc.values[key]; ok {
+			return Location{File: doc.Path, Line: manifest.FindNodeLine(doc.node, key)}
+		}
+	}
+	return in.defaultLocation()
+}
+
+// locateMapKey returns the location of a key in a map, for example customActions.NAME.
+func (in *Input) locateMapKey(key string, name string) Location {
+	for _, doc := range in.getDocuments() {
+		values, ok := doc.values[key].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := values[name]; ok {
+			return Location{File: doc.Path, Line: manifest.FindNodeLine(doc.node, key, name)}
+		}
+	}
+	return in.locateKey(key)
+}
+
+// locateNamedItem returns the location of the item with the specified name in a list,
+// for example the parameter NAME in parameters.
+func (in *Input) locateNamedItem(key string, name string) Location {
+	for _, doc := range in.getDocuments() {
+		items, _ := doc.values[key].([]interface{})
+		for i, item := range items {
+			itemMap, ok := item.(map[interface{}]interface{})
+			if ok && itemMap["name"] == name {
+				return Location{File: doc.Path, Line: manifest.FindNodeLine(doc.node, key, strconv.Itoa(i))}
+			}
+		}
+	}
+	return in.locateKey(key)
+}
+
+// locateStep returns the location of the step with the specified description in an action,
+// including the steps in a parallel step group. The steps from a step fragment are located
+// at the action.
+func (in *Input) locateStep(action string, description string) Location {
+	for _, doc := range in.getDocuments() {
+		steps, _ := doc.values[action].([]interface{})
+		for i, step := range steps {
+			if getStepDescription(step) == description {
+				return Location{File: doc.Path, Line: manifest.FindNodeLine(doc.node, action, strconv.Itoa(i))}
+			}
+
+			stepMap, _ := step.(map[interface{}]interface{})
+			group, _ := stepMap["parallel"].([]interface{})
+			for j, groupStep := range group {
+				if getStepDescription(groupStep) == description {
+					return Location{File: doc.Path, Line: manifest.FindNodeLine(doc.node, action, strconv.Itoa(i), "parallel", strconv.Itoa(j))}
+				}
+			}
+		}
+	}
+	return in.locateKey(action)
+}
+
+// getStepDescription returns the description of a step in an unmarshaled file.
+func getStepDescription(step interface{}) string {
+	stepMap, ok := step.(map[interface{}]interface{})
+	if !ok {
+		return ""
+	}
+
+	for _, value := range stepMap {
+		if data, ok := value.(map[interface{}]interface{}); ok {
+			if description, ok := data["description"].(string); ok {
+				return description
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,399 @@
+package linter
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"get.porter.sh/porter/pkg/manifest"
+	"github.com/docker/distribution/reference"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultRules are the rules that porter lint checks.
+func DefaultRules() []Rule {
+	return []Rule{
+		unusedParameterRule{},
+		undefinedOutputRule{},
+		echoedSensitiveParameterRule{},
+		unversionedTagRule{},
+		customActionDescriptionRule{},
+		statusActionRule{},
+	}
+}
+
+// templateReferencePattern matches a reference to a value of the bundle in a template,
+// for example {{ bundle.parameters.NAME }}.
+var templateReferencePattern = regexp.MustCompile(`{{{?\s*bundle\.(parameters|credentials|outputs)\.([a-zA-Z0-9_-]+)\s*}?}}`)
+
+// echoPattern matches a shell command that prints its arguments.
+var echoPattern = regexp.MustCompile(`\b(echo|printf)\b`)
+
+// actionStep is a step in an action of the manifest.
+type actionStep struct {
+	action      string
+	description string
+	step        *manifest.Step
+}
+
+// getActionSteps returns the steps of every action, including the steps in parallel step groups.
+func getActionSteps(m *manifest.Manifest) []actionStep {
+	actions := map[string]manifest.Steps{
+		string(manifest.ActionInstall):   m.Install,
+		string(manifest.ActionUpgrade):   m.Upgrade,
+		string(manifest.ActionUninstall): m.Uninstall,
+	}
+	for action, steps := range m.CustomActions {
+		actions[action] = steps
+	}
+
+	names := make([]string, 0, len(actions))
+	for action := range actions {
+		names = append(names, action)
+	}
+	sort.Strings(names)
+
+	var result []actionStep
+	for _, action := range names {
+		for _, step := range actions[action] {
+			if step == nil {
+				continue
+			}
+			if step.IsParallel() {
+				for _, groupStep := range step.Parallel {
+					if groupStep != nil {
+						result = append(result, newActionStep(action, groupStep))
+					}
+				}
+				continue
+			}
+			result = append(result, newActionStep(action, step))
+		}
+	}
+	return result
+}
+
+func newActionStep(action string, step *manifest.Step) actionStep {
+	description, _ := step.GetDescription()
+	return actionStep{action: action, description: description, step: step}
+}
+
+// templateReference is a reference to a value of the bundle, for example bundle.parameters.NAME.
+type templateReference struct {
+	kind string
+	name string
+}
+
+// getTemplateReferences returns the references to values of the bundle in a string.
+func getTemplateReferences(value string) []templateReference {
+	var refs []templateReference
+	for _, match := range templateReferencePattern.FindAllStringSubmatch(value, -1) {
+		refs = append(refs, templateReference{kind: match[1], name: match[2]})
+	}
+	return refs
+}
+
+// getStepReferences returns the references to values of the bundle in a step, including its condition.
+func getStepReferences(step *manifest.Step) []templateReference {
+	data, err := yaml.Marshal(step)
+	if err != nil {
+		return nil
+	}
+	return getTemplateReferences(string(data))
+}
+
+type unusedParameterRule struct{}
+
+func (unusedParameterRule) Code() string {
+	return "porter-101"
+}
+
+func (unusedParameterRule) Title() string {
+	return "Unused parameter"
+}
+
+// Lint finds parameters that are not referenced by any step or dependency. A parameter
+// with an explicit env or path is assumed to be used by a script.
+func (r unusedParameterRule) Lint(input *Input) Results {
+	m := input.Manifest
+	used := map[string]bool{}
+	for _, s := range getActionSteps(m) {
+		for _, ref := range getStepReferences(s.step) {
+			if ref.kind == "parameters" {
+				used[ref.name] = true
+			}
+		}
+	}
+	for _, dep := range m.Dependencies {
+		for _, value := range dep.Parameters {
+			for _, ref := range getTemplateReferences(value) {
+				if ref.kind == "parameters" {
+					used[ref.name] = true
+				}
+			}
+		}
+	}
+
+	results := Results{}
+	for _, param := range m.Parameters {
+		if used[param.Name] || !param.Destination.IsEmpty() {
+			continue
+		}
+
+		results = append(results, Result{
+			Level:    LevelWarning,
+			Code:     r.Code(),
+			Title:    r.Title(),
+			Message:  fmt.Sprintf("parameter %s is not used by any step. Reference it in a step with {{ bundle.parameters.%s }}, or set its env or path when a script reads it", param.Name, param.Name),
+			Location: input.locateNamedItem("parameters", param.Name),
+		})
+	}
+	return results
+}
+
+type undefinedOutputRule struct{}
+
+func (undefinedOutputRule) Code() string {
+	return "porter-102"
+}
+
+func (undefinedOutputRule) Title() string {
+	return "Undefined output"
+}
+
+// Lint finds references to bundle.outputs.NAME where NAME is not an output
+// of the bundle, or an output of a step.
+func (r undefinedOutputRule) Lint(input *Input) Results {
+	m := input.Manifest
+	steps := getActionSteps(m)
+
+	defined := map[string]bool{}
+	for _, output := range m.Outputs {
+		defined[output.Name] = true
+	}
+	for _, s := range steps {
+		for _, output := range s.step.GetOutputNames() {
+			defined[output] = true
+		}
+	}
+
+	results := Results{}
+	for _, s := range steps {
+		reported := map[string]bool{}
+		for _, ref := range getStepReferences(s.step) {
+			if ref.kind != "outputs" || defined[ref.name] || reported[ref.name] {
+				continue
+			}
+			reported[ref.name] = true
+
+			results = append(results, Result{
+				Level:    LevelError,
+				Code:     r.Code(),
+				Title:    r.Title(),
+				Message:  fmt.Sprintf("step %q in the %s action references the output %s, which is not defined by the bundle or any step", s.description, s.action, ref.name),
+				Location: input.locateStep(s.action, s.description),
+			})
+		}
+	}
+	return results
+}
+
+type echoedSensitiveParameterRule struct{}
+
+func (echoedSensitiveParameterRule) Code() string {
+	return "porter-103"
+}
+
+func (echoedSensitiveParameterRule) Title() string {
+	return "Sensitive parameter printed"
+}
+
+// Lint finds exec steps that print a sensitive parameter with echo or printf.
+func (r echoedSensitiveParameterRule) Lint(input *Input) Results {
+	m := input.Manifest
+	sensitive := map[string]bool{}
+	for _, param := range m.Parameters {
+		if param.Sensitive {
+			sensitive[param.Name] = true
+		}
+	}
+
+	results := Results{}
+	for _, s := range getActionSteps(m) {
+		if s.step.GetMixinName() != "exec" {
+			continue
+		}
+
+		command, values := getExecCommand(s.step)
+		commandEchoes := echoPattern.MatchString(path.Base(command))
+
+		reported := map[string]bool{}
+		for _, value := range values {
+			for _, loc := range templateReferencePattern.FindAllStringSubmatchIndex(value, -1) {
+				kind, name := value[loc[2]:loc[3]], value[loc[4]:loc[5]]
+				if kind != "parameters" || !sensitive[name] || reported[name] {
+					continue
+				}
+				if !commandEchoes && !echoPattern.MatchString(value[:loc[0]]) {
+					continue
+				}
+				reported[name] = true
+
+				results = append(results, Result{
+					Level:    LevelError,
+					Code:     r.Code(),
+					Title:    r.Title(),
+					Message:  fmt.Sprintf("step %q in the %s action prints the sensitive parameter %s", s.description, s.action, name),
+					Location: input.locateStep(s.action, s.description),
+				})
+			}
+		}
+	}
+	return results
+}
+
+// getExecCommand returns the command of an exec step, and the strings passed to the
+// command: the command itself, its arguments and the values of its flags.
+func getExecCommand(step *manifest.Step) (string, []string) {
+	data, ok := step.Data["exec"].(map[interface{}]interface{})
+	if !ok {
+		return "", nil
+	}
+
+	command, _ := data["command"].(string)
+	values := []string{command}
+	if args, ok := data["arguments"].([]interface{}); ok {
+		for _, arg := range args {
+			values = append(values, fmt.Sprintf("%v", arg))
+		}
+	}
+	if flags, ok := data["flags"].(map[interface{}]interface{}); ok {
+		for _, value := range flags {
+			values = append(values, fmt.Sprintf("%v", value))
+		}
+	}
+	return command, values
+}
+
+type unversionedTagRule struct{}
+
+func (unversionedTagRule) Code() string {
+	return "porter-104"
+}
+
+func (unversionedTagRule) Title() string {
+	return "Unversioned tag"
+}
+
+// Lint finds a bundle tag without a version, or the latest tag, which is
+// overwritten each time the bundle is published.
+func (r unversionedTagRule) Lint(input *Input) Results {
+	m := input.Manifest
+	if m.BundleTag == "" {
+		return nil
+	}
+
+	ref, err := reference.ParseNormalizedNamed(m.BundleTag)
+	if err != nil {
+		// An invalid tag is reported when the bundle is published
+		return nil
+	}
+	if _, ok := ref.(reference.Digested); ok {
+		return nil
+	}
+	if tagged, ok := ref.(reference.Tagged); ok && tagged.Tag() != "latest" {
+		return nil
+	}
+
+	return Results{{
+		Level:    LevelWarning,
+		Code:     r.Code(),
+		Title:    r.Title(),
+		Message:  fmt.Sprintf("the bundle tag %s does not specify a version, for example %s:v%s", m.BundleTag, reference.TrimNamed(ref).String(), m.Version),
+		Location: input.locateKey("tag"),
+	}}
+}
+
+type customActionDescriptionRule struct{}
+
+func (customActionDescriptionRule) Code() string {
+	return "porter-105"
+}
+
+func (customActionDescriptionRule) Title() string {
+	return "Custom action without a description"
+}
+
+// Lint finds custom actions in the generated bundle that do not have a description.
+// Porter uses the name of the action when it isn't described in customActions.
+func (r customActionDescriptionRule) Lint(input *Input) Results {
+	if input.Bundle == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(input.Bundle.Actions))
+	for name := range input.Bundle.Actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := Results{}
+	for _, name := range names {
+		description := input.Bundle.Actions[name].Description
+		if description != "" && description != name {
+			continue
+		}
+
+		location := input.locateMapKey("customActions", name)
+		if _, ok := input.Manifest.CustomActionDefinitions[name]; !ok {
+			location = input.locateKey(name)
+		}
+
+		results = append(results, Result{
+			Level:    LevelWarning,
+			Code:     r.Code(),
+			Title:    r.Title(),
+			Message:  fmt.Sprintf("custom action %s does not have a description. Describe it in customActions so that users know what it does", name),
+			Location: location,
+		})
+	}
+	return results
+}
+
+type statusActionRule struct{}
+
+func (statusActionRule) Code() string {
+	return "porter-106"
+}
+
+func (statusActionRule) Title() string {
+	return "Status action is not read-only"
+}
+
+// Lint finds a status action that modifies resources or is stateless. porter instances status
+// executes the status action against an installation to detect drift, which requires that
+// the action reads the installation without changing it.
+func (r statusActionRule) Lint(input *Input) Results {
+	if input.Bundle == nil {
+		return nil
+	}
+
+	action, ok := input.Bundle.Actions["status"]
+	if !ok || (!action.Modifies && !action.Stateless) {
+		return nil
+	}
+
+	location := input.locateMapKey("customActions", "status")
+	if _, ok := input.Manifest.CustomActionDefinitions["status"]; !ok {
+		location = input.locateKey("status")
+	}
+
+	return Results{{
+		Level:    LevelWarning,
+		Code:     r.Code(),
+		Title:    r.Title(),
+		Message:  "the status action should set modifies and stateless to false so that porter instances status can use it to detect drift",
+		Location: location,
+	}}
+}
@@ -0,0 +1,249 @@
+package manifest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// StepFragment is a named list of steps that can be used by any action, with
+// "- fragment: NAME". Its parameters are specified on the step with "with".
+type StepFragment struct {
+	// Parameters are the values that are substituted into the steps of the fragment.
+	Parameters []FragmentParameter `yaml:"parameters,omitempty"`
+
+	// Steps are the steps that replace a step that uses the fragment.
+	Steps Steps `yaml:"steps"`
+}
+
+// FragmentParameter is a value that is substituted into the steps of a fragment
+// wherever {{ fragment.NAME }} is used.
+type FragmentParameter struct {
+	Name string `yaml:"name"`
+
+	// Default is the value used when the step using the fragment does not specify the parameter.
+	// Parameters without a default are required.
+	Default interface{} `yaml:"default,omitempty"`
+}
+
+// fragmentParameterPattern matches a fragment parameter in the steps of a fragment, {{ fragment.NAME }}.
+var fragmentParameterPattern = regexp.MustCompile(`{{\s*fragment\.([a-zA-Z0-9_-]+)\s*}}`)
+
+// stepFragmentKeys are the only keys allowed on a step that uses a fragment.
+var stepFragmentKeys = map[interface{}]bool{"fragment": true, "with": true}
+
+// rawStepFragment is a step fragment before its steps have been expanded.
+type rawStepFragment struct {
+	Parameters []FragmentParameter `yaml:"parameters,omitempty"`
+	Steps      []interface{}       `yaml:"steps"`
+}
+
+// expandStepFragments replaces the steps in each action of a manifest document
+// that use a step fragment with the steps of the fragment.
+func expandStepFragments(doc map[string]interface{}) error {
+	fragments, err := readStepFragments(doc)
+	if err != nil {
+		return err
+	}
+
+	knownFields := getKnownManifestFields()
+	for key, value := range doc {
+		// Every key that isn't a known field is a custom action
+		isAction := key == "install" || key == "upgrade" || key == "uninstall" || !knownFields[key]
+		if !isAction {
+			continue
+		}
+
+		steps, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+
+		expanded, err := expandSteps(fragments, steps, nil)
+		if err != nil {
+			return errors.Wrapf(err, "could not expand the step fragments used by the %s action", key)
+		}
+		doc[key] = expanded
+	}
+
+	return nil
+}
+
+func readStepFragments(doc map[string]interface{}) (map[string]rawStepFragment, error) {
+	fragments := map[string]rawStepFragment{}
+
+	value, ok := doc["stepFragments"]
+	if !ok || value == nil {
+		return fragments, nil
+	}
+
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "error remarshaling the step fragments")
+	}
+
+	err = yaml.Unmarshal(data, &fragments)
+	return fragments, errors.Wrap(err, "error unmarshaling the step fragments")
+}
+
+// expandSteps replaces the steps that use a fragment, including the steps in a
+// parallel step group, with the steps of the fragment. The fragments that are
+// being expanded are tracked to detect a fragment that uses itself.
+func expandSteps(fragments map[string]rawStepFragment, steps []interface{}, expanding []string) ([]interface{}, error) {
+	expanded := make([]interface{}, 0, len(steps))
+	for _, step := range steps {
+		stepMap, ok := step.(map[interface{}]interface{})
+		if !ok {
+			expanded = append(expanded, step)
+			continue
+		}
+
+		if _, ok := stepMap["fragment"]; ok {
+			fragmentSteps, err := expandFragmentStep(fragments, stepMap, expanding)
+			if err != nil {
+				return nil, err
+			}
+			expanded = append(expanded, fragmentSteps...)
+			continue
+		}
+
+		if group, ok := stepMap["parallel"].([]interface{}); ok {
+			groupSteps, err := expandSteps(fragments, group, expanding)
+			if err != nil {
+				return nil, err
+			}
+			stepMap["parallel"] = groupSteps
+		}
+		expanded = append(expanded, stepMap)
+	}
+	return expanded, nil
+}
+
+// expandFragmentStep returns the steps of the fragment used by a step, with the
+// fragment's parameters substituted.
+func expandFragmentStep(fragments map[string]rawStepFragment, step map[interface{}]interface{}, expanding []string) ([]interface{}, error) {
+	name, ok := step["fragment"].(string)
+	if !ok || name == "" {
+		return nil, errors.Errorf("invalid step fragment %v, expected the name of a fragment", step["fragment"])
+	}
+
+	for key := range step {
+		if !stepFragmentKeys[key] {
+			return nil, errors.Errorf("invalid step using the fragment %s, only fragment and with may be specified but %v was also specified", name, key)
+		}
+	}
+
+	for _, f := range expanding {
+		if f == name {
+			return nil, errors.Errorf("the step fragment %s uses itself", name)
+		}
+	}
+
+	fragment, ok := fragments[name]
+	if !ok {
+		return nil, errors.Errorf("the step fragment %s is not defined", name)
+	}
+
+	values, err := resolveFragmentParameters(name, fragment, step["with"])
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := renderFragmentValue(name, values, fragment.Steps)
+	if err != nil {
+		return nil, err
+	}
+
+	return expandSteps(fragments, rendered.([]interface{}), append(expanding, name))
+}
+
+// resolveFragmentParameters returns the value of each parameter of the fragment,
+// using the values specified by the step, or the parameter's default.
+func resolveFragmentParameters(name string, fragment rawStepFragment, with interface{}) (map[string]interface{}, error) {
+	specified := map[interface{}]interface{}{}
+	if with != nil {
+		var ok bool
+		specified, ok = with.(map[interface{}]interface{})
+		if !ok {
+			return nil, errors.Errorf("invalid parameters for the step fragment %s, expected a map but got %T", name, with)
+		}
+	}
+
+	values := make(map[string]interface{}, len(fragment.Parameters))
+	for _, param := range fragment.Parameters {
+		value, ok := specified[param.Name]
+		if !ok {
+			if param.Default == nil {
+				return nil, errors.Errorf("the step fragment %s requires the parameter %s", name, param.Name)
+			}
+			value = param.Default
+		}
+		values[param.Name] = value
+	}
+
+	var unknown []string
+	for key := range specified {
+		if _, ok := values[fmt.Sprintf("%v", key)]; !ok {
+			unknown = append(unknown, fmt.Sprintf("%v", key))
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, errors.Errorf("the step fragment %s does not define the parameters %v", name, unknown)
+	}
+
+	return values, nil
+}
+
+// renderFragmentValue returns a copy of a value from the steps of a fragment, with
+// the fragment parameters substituted. When a string is only a parameter, it is
+// replaced with the parameter's value so that lists and maps can be passed to a fragment.
+func renderFragmentValue(name string, values map[string]interface{}, value interface{}) (interface{}, error) {
+	switch t := value.(type) {
+	case string:
+		if match := fragmentParameterPattern.FindStringSubmatch(t); match != nil && match[0] == t {
+			paramValue, ok := values[match[1]]
+			if !ok {
+				return nil, errors.Errorf("the step fragment %s uses the parameter %s, which is not defined", name, match[1])
+			}
+			return paramValue, nil
+		}
+
+		var err error
+		rendered := fragmentParameterPattern.ReplaceAllStringFunc(t, func(param string) string {
+			paramName := fragmentParameterPattern.FindStringSubmatch(param)[1]
+			paramValue, ok := values[paramName]
+			if !ok {
+				err = errors.Errorf("the step fragment %s uses the parameter %s, which is not defined", name, paramName)
+				return param
+			}
+			return fmt.Sprintf("%v", paramValue)
+		})
+		return rendered, err
+	case map[interface{}]interface{}:
+		rendered := make(map[interface{}]interface{}, len(t))
+		for key, item := range t {
+			renderedItem, err := renderFragmentValue(name, values, item)
+			if err != nil {
+				return nil, err
+			}
+			rendered[key] = renderedItem
+		}
+		return rendered, nil
+	case []interface{}:
+		rendered := make([]interface{}, len(t))
+		for i, item := range t {
+			renderedItem, err := renderFragmentValue(name, values, item)
+			if err != nil {
+				return nil, err
+			}
+			rendered[i] = renderedItem
+		}
+		return rendered, nil
+	default:
+		return value, nil
+	}
+}
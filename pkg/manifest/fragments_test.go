@@ -0,0 +1,104 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestUnmarshalManifest_StepFragments(t *testing.T) {
+	data := `
+mixins:
+- exec
+stepFragments:
+  greet:
+    parameters:
+    - name: greeting
+    - name: flags
+      default:
+        c: echo default
+    steps:
+    - exec:
+        description: "Say {{ fragment.greeting }}"
+        command: bash
+        flags: "{{ fragment.flags }}"
+        arguments:
+        - "{{ bundle.parameters.name }}"
+  greet-twice:
+    parameters:
+    - name: greeting
+    steps:
+    - fragment: greet
+      with:
+        greeting: "{{ fragment.greeting }}"
+    - fragment: greet
+      with:
+        greeting: "{{ fragment.greeting }} again"
+install:
+- fragment: greet-twice
+  with:
+    greeting: Hello
+- fragment: greet
+  with:
+    greeting: Goodbye
+    flags:
+      c: echo Goodbye
+`
+	m, err := UnmarshalManifest([]byte(data))
+	require.NoError(t, err)
+
+	var descriptions []string
+	for _, step := range m.Install {
+		description, err := step.GetDescription()
+		require.NoError(t, err)
+		descriptions = append(descriptions, description)
+	}
+	assert.Equal(t, []string{"Say Hello", "Say Hello again", "Say Goodbye"}, descriptions)
+
+	stepYaml, err := yaml.Marshal(m.Install[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(stepYaml), "c: echo default", "a parameter used as the whole value should keep its type")
+	assert.Contains(t, string(stepYaml), "'{{ bundle.parameters.name }}'", "other templates should be left for the runtime")
+
+	stepYaml, err = yaml.Marshal(m.Install[2])
+	require.NoError(t, err)
+	assert.Contains(t, string(stepYaml), "c: echo Goodbye")
+}
+
+func TestUnmarshalManifest_StepFragments_Errors(t *testing.T) {
+	fragments := `
+stepFragments:
+  greet:
+    parameters:
+    - name: greeting
+    steps:
+    - exec:
+        description: "Say {{ fragment.greeting }} to {{ fragment.name }}"
+  loop:
+    steps:
+    - fragment: loop
+`
+	testcases := []struct {
+		name    string
+		install string
+		wantErr string
+	}{
+		{"undefined fragment", "- fragment: nope", "the step fragment nope is not defined"},
+		{"missing parameter", "- fragment: greet", "the step fragment greet requires the parameter greeting"},
+		{"unknown parameter", "- fragment: greet\n  with: {greeting: hi, other: 1}", "the step fragment greet does not define the parameters [other]"},
+		{"undefined parameter", "- fragment: greet\n  with: {greeting: hi}", "the step fragment greet uses the parameter name, which is not defined"},
+		{"extra keys", "- fragment: greet\n  if: true", "invalid step using the fragment greet, only fragment and with may be specified but if was also specified"},
+		{"recursive", "- fragment: loop", "the step fragment loop uses itself"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := UnmarshalManifest([]byte(fragments + "install:\n" + tc.install))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "could not expand the step fragments used by the install action")
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
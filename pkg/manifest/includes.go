@@ -0,0 +1,277 @@
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"get.porter.sh/porter/pkg/context"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// manifestLoader reads a manifest and merges the files that it includes into it.
+type manifestLoader struct {
+	// root is the location of the manifest.
+	root string
+
+	// read returns the contents of the file at a location.
+	read func(location string) ([]byte, error)
+
+	// resolve returns the location of a file included by another file.
+	resolve func(parent string, include string) (string, error)
+
+	// included are the locations of the included files, in the order that they were loaded.
+	included []string
+
+	// files are the files that were read, starting with the manifest.
+	files []ManifestFile
+
+	// loading are the locations of the files that are being loaded, used to detect circular includes.
+	loading []string
+}
+
+// newFileManifestLoader creates a loader for a manifest on the filesystem.
+// Included files must be in the same directory as the manifest, or a subdirectory,
+// so that they are copied into the invocation image with the manifest.
+func newFileManifestLoader(cxt *context.Context, manifestPath string) *manifestLoader {
+	bundleDir := filepath.Dir(manifestPath)
+	return &manifestLoader{
+		root: manifestPath,
+		read: func(location string) ([]byte, error) {
+			data, err := cxt.FileSystem.ReadFile(location)
+			return data, errors.Wrapf(err, "could not read manifest at %q", location)
+		},
+		resolve: func(parent string, include string) (string, error) {
+			if filepath.IsAbs(include) {
+				return "", errors.Errorf("invalid include %s in %s, it must be a relative path", include, parent)
+			}
+
+			location := filepath.Join(filepath.Dir(parent), include)
+			rel, err := filepath.Rel(bundleDir, location)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return "", errors.Errorf("invalid include %s in %s, it must be in the bundle directory", include, parent)
+			}
+			return location, nil
+		},
+	}
+}
+
+// newURLManifestLoader creates a loader for a manifest at a url.
+// Included files are resolved relative to the url of the manifest.
+func newURLManifestLoader(manifestURL string) (*manifestLoader, error) {
+	rootURL, err := url.Parse(manifestURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid manifest url %s", manifestURL)
+	}
+	bundleDir := path.Dir(rootURL.Path)
+
+	return &manifestLoader{
+		root: manifestURL,
+		read: func(location string) ([]byte, error) {
+			resp, err := http.Get(location)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not reach url %s", location)
+			}
+
+			defer resp.Body.Close()
+			data, err := ioutil.ReadAll(resp.Body)
+			return data, errors.Wrapf(err, "could not read from url %s", location)
+		},
+		resolve: func(parent string, include string) (string, error) {
+			includeURL, err := url.Parse(include)
+			if err != nil || includeURL.IsAbs() || path.IsAbs(includeURL.Path) {
+				return "", errors.Errorf("invalid include %s in %s, it must be a relative path", include, parent)
+			}
+
+			parentURL, err := url.Parse(parent)
+			if err != nil {
+				return "", errors.Wrapf(err, "invalid manifest url %s", parent)
+			}
+
+			location := parentURL.ResolveReference(includeURL)
+			if !strings.HasPrefix(location.Path, strings.TrimSuffix(bundleDir, "/")+"/") {
+				return "", errors.Errorf("invalid include %s in %s, it must be in the bundle directory", include, parent)
+			}
+			return location.String(), nil
+		},
+	}, nil
+}
+
+// Load reads the manifest, merges the files that it includes and expands any step fragments.
+func (l *manifestLoader) Load() (*Manifest, error) {
+	data, err := l.read(l.root)
+	if err != nil {
+		return nil, err
+	}
+	l.files = append(l.files, ManifestFile{Location: l.root, Data: data})
+
+	doc, err := l.loadDocument(l.root, data)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := unmarshalManifestFromDocument(doc, data)
+	if err != nil {
+		return nil, err
+	}
+
+	m.IncludedFiles = l.included
+	m.Files = l.files
+	return m, nil
+}
+
+// loadDocument unmarshals a manifest document and merges the documents that it includes into it.
+func (l *manifestLoader) loadDocument(location string, data []byte) (map[string]interface{}, error) {
+	doc, err := unmarshalManifestDocument(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading %s", location)
+	}
+
+	includes, err := getManifestIncludes(doc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading %s", location)
+	}
+
+	l.loading = append(l.loading, location)
+	defer func() { l.loading = l.loading[:len(l.loading)-1] }()
+
+	for _, include := range includes {
+		includeLocation, err := l.resolve(location, include)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, loading := range l.loading {
+			if loading == includeLocation {
+				return nil, errors.Errorf("circular include of %s in %s", include, location)
+			}
+		}
+
+		// A file included by more than one file is only merged once
+		if l.isIncluded(includeLocation) {
+			continue
+		}
+
+		includeData, err := l.read(includeLocation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not include %s in %s", include, location)
+		}
+		l.included = append(l.included, includeLocation)
+		l.files = append(l.files, ManifestFile{Location: includeLocation, Data: includeData})
+
+		includeDoc, err := l.loadDocument(includeLocation, includeData)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only the includes of the manifest itself are kept in the merged document
+		delete(includeDoc, "include")
+		err = mergeManifestDocuments(doc, includeDoc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not include %s in %s", include, location)
+		}
+	}
+
+	return doc, nil
+}
+
+func (l *manifestLoader) isIncluded(location string) bool {
+	for _, included := range l.included {
+		if included == location {
+			return true
+		}
+	}
+	return false
+}
+
+// unmarshalManifestDocument unmarshals a manifest into an untyped map.
+func unmarshalManifestDocument(data []byte) (map[string]interface{}, error) {
+	doc := make(map[string]interface{})
+	err := yaml.Unmarshal(data, &doc)
+	return doc, errors.Wrap(err, "error unmarshaling the manifest")
+}
+
+// getManifestIncludes returns the files included by a manifest document.
+func getManifestIncludes(doc map[string]interface{}) ([]string, error) {
+	value, ok := doc["include"]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("invalid include, expected a list of files but got %T", value)
+	}
+
+	includes := make([]string, 0, len(items))
+	for _, item := range items {
+		include, ok := item.(string)
+		if !ok || include == "" {
+			return nil, errors.Errorf("invalid include %v, expected the path to a file", item)
+		}
+		includes = append(includes, include)
+	}
+	return includes, nil
+}
+
+// mergeManifestDocuments merges an included document into a manifest document.
+// Lists, such as parameters or the steps of an action, are appended to the
+// list in the manifest, ignoring mixins that are already declared. Maps, such
+// as dependencies or step fragments, are merged but a key may only be defined
+// once. Any other value may only be defined once.
+func mergeManifestDocuments(doc map[string]interface{}, include map[string]interface{}) error {
+	for key, value := range include {
+		existing, ok := doc[key]
+		if !ok || existing == nil {
+			doc[key] = value
+			continue
+		}
+
+		switch existingT := existing.(type) {
+		case []interface{}:
+			valueT, ok := value.([]interface{})
+			if !ok {
+				return errors.Errorf("%s is a list in the manifest but %T in the included file", key, value)
+			}
+			for _, item := range valueT {
+				// The same mixin is often declared by the manifest and the files that it includes
+				if key == "mixins" && containsDocumentValue(existingT, item) {
+					continue
+				}
+				existingT = append(existingT, item)
+			}
+			doc[key] = existingT
+		case map[interface{}]interface{}:
+			valueT, ok := value.(map[interface{}]interface{})
+			if !ok {
+				return errors.Errorf("%s is a map in the manifest but %T in the included file", key, value)
+			}
+			for itemKey, item := range valueT {
+				if _, ok := existingT[itemKey]; ok {
+					return errors.Errorf("%s.%v is defined more than once", key, itemKey)
+				}
+				existingT[itemKey] = item
+			}
+		default:
+			if fmt.Sprintf("%v", existing) != fmt.Sprintf("%v", value) {
+				return errors.Errorf("%s is defined more than once", key)
+			}
+		}
+	}
+	return nil
+}
+
+func containsDocumentValue(items []interface{}, value interface{}) bool {
+	for _, item := range items {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
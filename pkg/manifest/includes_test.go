@@ -0,0 +1,120 @@
+package manifest
+
+import (
+	"testing"
+
+	"get.porter.sh/porter/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadManifest_Includes(t *testing.T) {
+	cxt := context.NewTestContext(t)
+	cxt.AddTestDirectory("testdata/includes", ".")
+
+	m, err := LoadManifestFrom(cxt.Context, "porter.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"parameters.yaml", "fragments/helm.yaml", "fragments/mixins.yaml"}, m.IncludedFiles)
+	require.Len(t, m.Files, 4, "the manifest and the files that it includes should be kept")
+	for i, location := range []string{"porter.yaml", "parameters.yaml", "fragments/helm.yaml", "fragments/mixins.yaml"} {
+		data, err := cxt.FileSystem.ReadFile(location)
+		require.NoError(t, err)
+		assert.Equal(t, ManifestFile{Location: location, Data: data}, m.Files[i])
+	}
+	assert.Equal(t, []string{"parameters.yaml", "fragments/helm.yaml"}, m.Include)
+	assert.Equal(t, []MixinDeclaration{{Name: "exec"}, {Name: "helm"}}, m.Mixins, "mixins declared by more than one file should only be declared once")
+
+	require.Len(t, m.Parameters, 2)
+	assert.Equal(t, "namespace", m.Parameters[0].Name)
+	assert.Equal(t, "chart-version", m.Parameters[1].Name)
+
+	require.Len(t, m.Install, 2, "the fragment should be expanded into the install action")
+	description, err := m.Install[0].GetDescription()
+	require.NoError(t, err)
+	assert.Equal(t, "Helm install mysql", description)
+	assert.Equal(t, "exec", m.Install[1].GetMixinName())
+
+	require.Contains(t, m.CustomActions, "status")
+	description, err = m.CustomActions["status"][0].GetDescription()
+	require.NoError(t, err)
+	assert.Equal(t, "Helm status mysql", description)
+}
+
+func TestReadManifest_Includes_Errors(t *testing.T) {
+	testcases := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name: "missing file",
+			files: map[string]string{
+				"porter.yaml": "include: [missing.yaml]",
+			},
+			wantErr: "could not include missing.yaml in porter.yaml",
+		},
+		{
+			name: "outside the bundle directory",
+			files: map[string]string{
+				"bundle/porter.yaml": "include: [../shared.yaml]",
+				"shared.yaml":        "name: shared",
+			},
+			wantErr: "invalid include ../shared.yaml in bundle/porter.yaml, it must be in the bundle directory",
+		},
+		{
+			name: "absolute path",
+			files: map[string]string{
+				"porter.yaml": "include: [/shared.yaml]",
+			},
+			wantErr: "invalid include /shared.yaml in porter.yaml, it must be a relative path",
+		},
+		{
+			name: "circular include",
+			files: map[string]string{
+				"porter.yaml": "include: [a.yaml]",
+				"a.yaml":      "include: [b.yaml]",
+				"b.yaml":      "include: [a.yaml]",
+			},
+			wantErr: "circular include of a.yaml in b.yaml",
+		},
+		{
+			name: "duplicate value",
+			files: map[string]string{
+				"porter.yaml": "include: [a.yaml]\nname: hello",
+				"a.yaml":      "name: goodbye",
+			},
+			wantErr: "could not include a.yaml in porter.yaml: name is defined more than once",
+		},
+		{
+			name: "duplicate key",
+			files: map[string]string{
+				"porter.yaml": "include: [a.yaml]\nimages:\n  app:\n    repository: app",
+				"a.yaml":      "images:\n  app:\n    repository: other",
+			},
+			wantErr: "could not include a.yaml in porter.yaml: images.app is defined more than once",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cxt := context.NewTestContext(t)
+			manifestPath := ""
+			for path, contents := range tc.files {
+				require.NoError(t, cxt.AddTestFileContents([]byte(contents), path))
+				if path == "porter.yaml" || path == "bundle/porter.yaml" {
+					manifestPath = path
+				}
+			}
+
+			_, err := ReadManifest(cxt.Context, manifestPath)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestUnmarshalManifest_Includes(t *testing.T) {
+	_, err := UnmarshalManifest([]byte("include: [a.yaml]"))
+	require.EqualError(t, err, "the manifest includes other files, which is only supported when it is read from a file or url")
+}
@@ -0,0 +1,78 @@
+package manifest
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FindLine returns the line number in a YAML document of the value at the
+// specified path, where each element of the path is either the key in a map or
+// the index of an item in a list. For example the path install, 0, exec finds
+// the exec mixin data of the first install step.
+//
+// When the full path cannot be found, the line of the deepest part of the path that
+// was found is returned. Returns 0 when no part of the path is found, or the document
+// cannot be parsed.
+func FindLine(data []byte, path ...string) int {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return 0
+	}
+	return FindNodeLine(&doc, path...)
+}
+
+// FindNodeLine returns the line number of the value at the specified path in a parsed
+// YAML document, so that a document can be searched without parsing it again. See FindLine.
+func FindNodeLine(node *yaml.Node, path ...string) int {
+	line := 0
+	for _, element := range path {
+		node = resolveYAMLNode(node)
+		if node == nil {
+			break
+		}
+
+		// The line of a key is reported, instead of the line of its value which may be on the next line
+		var found, value *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == element {
+					found, value = node.Content[i], node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(element)
+			if err == nil && index >= 0 && index < len(node.Content) {
+				found, value = node.Content[index], node.Content[index]
+			}
+		}
+		if found == nil {
+			break
+		}
+
+		line = found.Line
+		node = value
+	}
+
+	return line
+}
+
+// resolveYAMLNode returns the node that holds the value of a document or an alias.
+func resolveYAMLNode(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			if len(node.Content) == 0 {
+				return nil
+			}
+			node = node.Content[0]
+		case yaml.AliasNode:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+	return nil
+}
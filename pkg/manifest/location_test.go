@@ -0,0 +1,97 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindLine(t *testing.T) {
+	data := []byte(`name: hello # the name
+mixins:
+- exec
+
+install:
+- exec:
+    description: "Say Hello"
+    command: bash
+    flags:
+      c: |
+        echo Hello
+        name: not a key
+- if: "{{ bundle.parameters.backup }}"
+  exec:
+    description: "Backup"
+
+parameters:
+  - name: backup
+    type: boolean
+  - name: "color"
+    default: blue
+`)
+
+	testcases := []struct {
+		path []string
+		want int
+	}{
+		{[]string{"name"}, 1},
+		{[]string{"mixins", "0"}, 3},
+		{[]string{"install"}, 5},
+		{[]string{"install", "0", "exec"}, 6},
+		{[]string{"install", "0", "exec", "flags", "c"}, 10},
+		{[]string{"install", "1"}, 13},
+		{[]string{"install", "1", "exec", "description"}, 15},
+		{[]string{"parameters", "1", "default"}, 21},
+		{[]string{"parameters", "1", "name"}, 20},
+		{[]string{"install", "1", "exec", "nope"}, 14},
+		{[]string{"install", "0", "exec", "name"}, 6},
+		{[]string{"nope"}, 0},
+	}
+
+	for _, tc := range testcases {
+		assert.Equal(t, tc.want, FindLine(data, tc.path...), "wrong line for %v", tc.path)
+	}
+}
+
+func TestFindLine_Styles(t *testing.T) {
+	data := []byte(`description: |+
+  keep the trailing newlines
+  name: not a key
+
+summary: >2
+   indented text
+   name: not a key
+mixins: [exec, helm]
+images: {app: {repository: deislabs/app}, db: {repository: mysql}}
+install:
+    -   exec:
+            description: "Deeply indented"
+    -
+        helm:
+            description: "Item on its own line"
+defaults: &defaults
+  region: eastus
+parameters: *defaults
+`)
+
+	testcases := []struct {
+		path []string
+		want int
+	}{
+		{[]string{"summary"}, 5},
+		{[]string{"name"}, 0},
+		{[]string{"mixins", "1"}, 8},
+		{[]string{"images", "db", "repository"}, 9},
+		{[]string{"install", "0", "exec", "description"}, 12},
+		{[]string{"install", "1", "helm"}, 14},
+		{[]string{"parameters", "region"}, 17},
+	}
+
+	for _, tc := range testcases {
+		assert.Equal(t, tc.want, FindLine(data, tc.path...), "wrong line for %v", tc.path)
+	}
+}
+
+func TestFindLine_Invalid(t *testing.T) {
+	assert.Equal(t, 0, FindLine([]byte("install: [exec"), "install"))
+}
@@ -2,11 +2,10 @@ package manifest
 
 import (
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"get.porter.sh/porter/pkg/context"
 	"github.com/cnabio/cnab-go/bundle/definition"
@@ -50,6 +49,29 @@ type Manifest struct {
 	// ImageMap is a map of images referenced in the bundle. If an image relocation mapping is later provided, that
 	// will be mounted at as a file at runtime to /cnab/app/relocation-mapping.json.
 	ImageMap map[string]MappedImage `yaml:"images,omitempty"`
+
+	// Include is a list of other manifest files, relative to the manifest, that are merged into the manifest.
+	Include []string `yaml:"include,omitempty"`
+
+	// StepFragments are named lists of steps that can be used by any action.
+	// The steps that use a fragment are replaced with the fragment's steps when the manifest is loaded.
+	StepFragments map[string]StepFragment `yaml:"stepFragments,omitempty"`
+
+	// IncludedFiles are the locations of the files included in the manifest, in the order that they were loaded.
+	IncludedFiles []string `yaml:"-"`
+
+	// Files are the manifest followed by the files that it includes, as they were read when the
+	// manifest was loaded. Use them instead of reading the files again, which may not be on the filesystem.
+	Files []ManifestFile `yaml:"-"`
+}
+
+// ManifestFile is a file that was read when loading a manifest.
+type ManifestFile struct {
+	// Location is where the file was read from, such as the path on the filesystem or a url.
+	Location string
+
+	// Data is the contents of the file.
+	Data []byte
 }
 
 func (m *Manifest) Validate() error {
@@ -120,9 +142,23 @@ type ParameterDefinition struct {
 	ApplyTo     []string `yaml:"applyTo,omitempty"`
 	Destination Location `yaml:",inline,omitempty"`
 
+	// Source is an output of an installation that is used as the default value of
+	// the parameter, when a value is not specified.
+	Source *ParameterSource `yaml:"source,omitempty"`
+
 	definition.Schema `yaml:",inline"`
 }
 
+// ParameterSource is an output of an installation that is used as the default value of a parameter.
+type ParameterSource struct {
+	// Installation is the name of the installation with the output. When empty, the
+	// output from the previous run of the installation being executed is used.
+	Installation string `yaml:"installation,omitempty"`
+
+	// Output is the name of the output.
+	Output string `yaml:"output"`
+}
+
 func (pd *ParameterDefinition) Validate() error {
 	var result *multierror.Error
 
@@ -130,6 +166,10 @@ func (pd *ParameterDefinition) Validate() error {
 		result = multierror.Append(result, errors.New("parameter name is required"))
 	}
 
+	if pd.Source != nil && pd.Source.Output == "" {
+		result = multierror.Append(result, fmt.Errorf("no output specified for the source of parameter %s", pd.Name))
+	}
+
 	// Porter supports declaring a parameter of type: "file",
 	// which we will convert to the appropriate bundle.Parameter type in adapter.go
 	// Here, we copy the ParameterDefinition and make the same modification before validation
@@ -389,10 +429,39 @@ func (s Steps) Validate(m *Manifest) error {
 }
 
 type Step struct {
+	// If is an optional condition that is evaluated before the step is executed.
+	// The step is skipped when the condition is not true.
+	If string `yaml:"if,omitempty"`
+
+	// Retries is the number of times to retry the step when it fails.
+	Retries int `yaml:"retries,omitempty"`
+
+	// RetryDelay is how long to wait before retrying a failed step, for example 10s.
+	RetryDelay string `yaml:"retryDelay,omitempty"`
+
+	// Timeout is how long each attempt of the step may run before it fails, for example 5m.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// ContinueOnError specifies if the action should continue when the step fails.
+	ContinueOnError bool `yaml:"continueOnError,omitempty"`
+
+	// Parallel is a group of steps that are executed concurrently. A step group
+	// does not have any mixin data.
+	Parallel Steps `yaml:"parallel,omitempty"`
+
 	Data map[string]interface{} `yaml:",inline"`
 }
 
+// IsParallel determines if the step is a group of steps that are executed concurrently.
+func (s *Step) IsParallel() bool {
+	return len(s.Parallel) > 0
+}
+
 func (s *Step) Validate(m *Manifest) error {
+	if s.IsParallel() {
+		return s.validateParallel(m)
+	}
+
 	if len(s.Data) == 0 {
 		return errors.New("no mixin specified")
 	}
@@ -416,12 +485,102 @@ func (s *Step) Validate(m *Manifest) error {
 		return err
 	}
 
+	if s.Retries < 0 {
+		return errors.Errorf("invalid retries %d, must be zero or greater", s.Retries)
+	}
+
+	if _, err := s.GetRetryDelay(); err != nil {
+		return err
+	}
+
+	if _, err := s.GetTimeout(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func (s *Step) validateParallel(m *Manifest) error {
+	if len(s.Data) > 0 {
+		return errors.New("a parallel step group cannot also specify a mixin")
+	}
+
+	if s.Retries != 0 || s.RetryDelay != "" || s.Timeout != "" || s.ContinueOnError {
+		return errors.New("retries, retryDelay, timeout and continueOnError are not supported on a parallel step group, set them on the steps in the group instead")
+	}
+
+	// All steps in the group write their outputs at the same time, so the output names must be unique
+	outputs := make(map[string]string)
+	for _, step := range s.Parallel {
+		if step == nil {
+			continue
+		}
+
+		if step.IsParallel() {
+			return errors.New("parallel step groups cannot be nested")
+		}
+
+		err := step.Validate(m)
+		if err != nil {
+			return err
+		}
+
+		description, _ := step.GetDescription()
+		for _, output := range step.GetOutputNames() {
+			if other, ok := outputs[output]; ok {
+				return errors.Errorf("output %s is declared by more than one step in a parallel step group: %q and %q", output, other, description)
+			}
+			outputs[output] = description
+		}
+	}
+
+	return nil
+}
+
+// GetRetryDelay returns how long to wait before retrying the step.
+func (s *Step) GetRetryDelay() (time.Duration, error) {
+	return parseStepDuration("retryDelay", s.RetryDelay)
+}
+
+// GetTimeout returns how long each attempt of the step may run.
+// Returns 0 when the step does not have a timeout.
+func (s *Step) GetTimeout() (time.Duration, error) {
+	return parseStepDuration("timeout", s.Timeout)
+}
+
+func parseStepDuration(field string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s %q", field, value)
+	}
+	if d < 0 {
+		return 0, errors.Errorf("invalid %s %q, must not be negative", field, value)
+	}
+	return d, nil
+}
+
 // GetDescription returns a description of the step.
 // Every step must have this property.
 func (s *Step) GetDescription() (string, error) {
+	if s.IsParallel() {
+		descriptions := make([]string, 0, len(s.Parallel))
+		for _, step := range s.Parallel {
+			if step == nil {
+				continue
+			}
+			d, err := step.GetDescription()
+			if err != nil {
+				return "", err
+			}
+			descriptions = append(descriptions, d)
+		}
+		return "Run in parallel: " + strings.Join(descriptions, ", "), nil
+	}
+
 	if s.Data == nil {
 		return "", errors.New("empty step data")
 	}
@@ -440,6 +599,37 @@ func (s *Step) GetDescription() (string, error) {
 	return desc, nil
 }
 
+// GetMixinStep returns a copy of the step with only the data that is handled
+// by the mixin, without any of the fields that are handled by Porter.
+func (s *Step) GetMixinStep() *Step {
+	return &Step{Data: s.Data}
+}
+
+// GetOutputNames returns the names of the outputs declared by the step.
+func (s *Step) GetOutputNames() []string {
+	children, ok := s.Data[s.GetMixinName()].(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+
+	outputs, ok := children["outputs"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		outputMap, ok := output.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := outputMap["name"].(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func (s *Step) GetMixinName() string {
 	var mixinName string
 	for k := range s.Data {
@@ -448,7 +638,49 @@ func (s *Step) GetMixinName() string {
 	return mixinName
 }
 
+// UnmarshalManifest reads a single manifest document. Manifests that include
+// other files must be read with ReadManifest.
 func UnmarshalManifest(manifestData []byte) (*Manifest, error) {
+	doc, err := unmarshalManifestDocument(manifestData)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := doc["include"]; ok {
+		return nil, errors.New("the manifest includes other files, which is only supported when it is read from a file or url")
+	}
+
+	m, err := unmarshalManifestFromDocument(doc, manifestData)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Files = []ManifestFile{{Data: manifestData}}
+	return m, nil
+}
+
+// unmarshalManifestFromDocument converts a manifest document, after any
+// included files are merged into it, into a manifest. The original data
+// is used when the document does not need to be expanded.
+func unmarshalManifestFromDocument(doc map[string]interface{}, manifestData []byte) (*Manifest, error) {
+	_, hasIncludes := doc["include"]
+	_, hasFragments := doc["stepFragments"]
+	if hasIncludes || hasFragments {
+		err := expandStepFragments(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		manifestData, err = yaml.Marshal(doc)
+		if err != nil {
+			return nil, errors.Wrap(err, "error remarshaling the expanded manifest")
+		}
+	}
+
+	return unmarshalManifestData(manifestData)
+}
+
+func unmarshalManifestData(manifestData []byte) (*Manifest, error) {
 	// Unmarshal the manifest into the normal struct
 	manifest := &Manifest{}
 	err := yaml.Unmarshal(manifestData, &manifest)
@@ -468,17 +700,10 @@ func UnmarshalManifest(manifestData []byte) (*Manifest, error) {
 		return nil, errors.Wrap(err, "error unmarshaling the untyped manifest")
 	}
 
-	// Use reflection to figure out which fields are on the manifest and have yaml tags
-	objValue := reflect.ValueOf(manifest).Elem()
-	knownFields := map[string]reflect.Value{}
-	for i := 0; i != objValue.NumField(); i++ {
-		tagName := strings.Split(objValue.Type().Field(i).Tag.Get("yaml"), ",")[0]
-		knownFields[tagName] = objValue.Field(i)
-	}
-
 	// Remove any fields that have yaml tags
+	knownFields := getKnownManifestFields()
 	for key := range unmappedData {
-		if _, found := knownFields[key]; found {
+		if knownFields[key] {
 			delete(unmappedData, key)
 		}
 	}
@@ -503,6 +728,19 @@ func UnmarshalManifest(manifestData []byte) (*Manifest, error) {
 	return manifest, nil
 }
 
+// getKnownManifestFields returns the names of the top level keys in the manifest
+// that are mapped with yaml tags. Any other key is a custom action.
+func getKnownManifestFields() map[string]bool {
+	// Use reflection to figure out which fields are on the manifest and have yaml tags
+	objType := reflect.TypeOf(Manifest{})
+	knownFields := make(map[string]bool, objType.NumField())
+	for i := 0; i != objType.NumField(); i++ {
+		tagName := strings.Split(objType.Field(i).Tag.Get("yaml"), ",")[0]
+		knownFields[tagName] = true
+	}
+	return knownFields
+}
+
 func (m *Manifest) SetDefaults() {
 	if m.Image == "" && m.BundleTag != "" {
 		registry := strings.Split(m.BundleTag, ":")[0]
@@ -515,37 +753,16 @@ func readFromFile(cxt *context.Context, path string) (*Manifest, error) {
 		return nil, errors.Errorf("the specified porter configuration file %s does not exist", path)
 	}
 
-	data, err := cxt.FileSystem.ReadFile(path)
-	if err != nil {
-		return nil, errors.Wrapf(err, "could not read manifest at %q", path)
-	}
-
-	m, err := UnmarshalManifest(data)
-	if err != nil {
-		return nil, err
-	}
-
-	return m, nil
+	l := newFileManifestLoader(cxt, path)
+	return l.Load()
 }
 
 func readFromURL(path string) (*Manifest, error) {
-	resp, err := http.Get(path)
-	if err != nil {
-		return nil, errors.Wrapf(err, "could not reach url %s", path)
-	}
-
-	defer resp.Body.Close()
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, errors.Wrapf(err, "could not read from url %s", path)
-	}
-
-	m, err := UnmarshalManifest(data)
+	l, err := newURLManifestLoader(path)
 	if err != nil {
 		return nil, err
 	}
-
-	return m, nil
+	return l.Load()
 }
 
 // ReadManifest determines if specified path is a URL or a filepath.
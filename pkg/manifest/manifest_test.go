@@ -3,6 +3,7 @@ package manifest
 import (
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"get.porter.sh/porter/pkg/config"
 	"get.porter.sh/porter/pkg/context"
@@ -108,6 +109,142 @@ func TestAction_Validate_RequireSingleMixinData(t *testing.T) {
 	assert.EqualError(t, err, "more than one mixin specified")
 }
 
+func TestStep_UnmarshalYAML_Condition(t *testing.T) {
+	data := []byte(`if: "{{ bundle.parameters.backup }}"
+exec:
+  description: Backup
+  command: ./backup.sh
+`)
+
+	var step Step
+	err := yaml.Unmarshal(data, &step)
+	require.NoError(t, err)
+
+	assert.Equal(t, "{{ bundle.parameters.backup }}", step.If)
+	assert.Len(t, step.Data, 1, "the condition should not be included in the mixin data")
+	assert.Equal(t, "exec", step.GetMixinName())
+
+	mixinStep, err := yaml.Marshal(step.GetMixinStep())
+	require.NoError(t, err)
+	assert.NotContains(t, string(mixinStep), "if:", "the condition should not be passed to the mixin")
+}
+
+func TestStep_Validate_Policies(t *testing.T) {
+	cxt := context.NewTestContext(t)
+	cxt.AddTestFile("testdata/simple.porter.yaml", config.Name)
+
+	m, err := LoadManifestFrom(cxt.Context, config.Name)
+	require.NoError(t, err, "could not load manifest")
+
+	testcases := []struct {
+		name    string
+		step    func(s Step) Step
+		wantErr string
+	}{
+		{"valid", func(s Step) Step {
+			s.Retries = 3
+			s.RetryDelay = "10s"
+			s.Timeout = "5m"
+			return s
+		}, ""},
+		{"negative retries", func(s Step) Step { s.Retries = -1; return s }, "invalid retries -1, must be zero or greater"},
+		{"invalid retryDelay", func(s Step) Step { s.RetryDelay = "soon"; return s }, `invalid retryDelay "soon": time: invalid duration "soon"`},
+		{"negative timeout", func(s Step) Step { s.Timeout = "-1m"; return s }, `invalid timeout "-1m", must not be negative`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			step := tc.step(*m.Install[0])
+			err := step.Validate(m)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestStep_GetTimeout(t *testing.T) {
+	s := Step{Timeout: "90s", RetryDelay: "1m"}
+
+	timeout, err := s.GetTimeout()
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, timeout)
+
+	delay, err := s.GetRetryDelay()
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, delay)
+
+	timeout, err = (&Step{}).GetTimeout()
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), timeout, "steps should not have a timeout by default")
+}
+
+func TestStep_Validate_Parallel(t *testing.T) {
+	m := &Manifest{Mixins: []MixinDeclaration{{Name: "exec"}}}
+	newStep := func(description string, outputs ...string) *Step {
+		outputsData := make([]interface{}, 0, len(outputs))
+		for _, o := range outputs {
+			outputsData = append(outputsData, map[interface{}]interface{}{"name": o})
+		}
+		return &Step{Data: map[string]interface{}{
+			"exec": map[interface{}]interface{}{
+				"description": description,
+				"outputs":     outputsData,
+			},
+		}}
+	}
+
+	testcases := []struct {
+		name    string
+		step    *Step
+		wantErr string
+	}{
+		{"valid", &Step{Parallel: Steps{newStep("a", "network"), newStep("b", "database")}}, ""},
+		{"mixin", &Step{Parallel: Steps{newStep("a")}, Data: newStep("b").Data}, "a parallel step group cannot also specify a mixin"},
+		{"policies", &Step{Parallel: Steps{newStep("a")}, Retries: 1}, "retries, retryDelay, timeout and continueOnError are not supported on a parallel step group, set them on the steps in the group instead"},
+		{"nested", &Step{Parallel: Steps{{Parallel: Steps{newStep("a")}}}}, "parallel step groups cannot be nested"},
+		{"invalid step", &Step{Parallel: Steps{{}}}, "no mixin specified"},
+		{"duplicate outputs", &Step{Parallel: Steps{newStep("a", "id"), newStep("b", "id")}}, `output id is declared by more than one step in a parallel step group: "a" and "b"`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.step.Validate(m)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestStep_UnmarshalYAML_Parallel(t *testing.T) {
+	data := []byte(`parallel:
+- exec:
+    description: Create network
+    command: ./network.sh
+- exec:
+    description: Create database
+    command: ./database.sh
+`)
+
+	var step Step
+	err := yaml.Unmarshal(data, &step)
+	require.NoError(t, err)
+
+	assert.True(t, step.IsParallel())
+	assert.Empty(t, step.Data, "a parallel step group should not have any mixin data")
+	require.Len(t, step.Parallel, 2)
+	assert.Equal(t, "exec", step.Parallel[0].GetMixinName())
+
+	description, err := step.GetDescription()
+	require.NoError(t, err)
+	assert.Equal(t, "Run in parallel: Create network, Create database", description)
+}
+
 func TestManifest_Validate_Dockerfile(t *testing.T) {
 	cxt := context.NewTestContext(t)
 
@@ -241,6 +378,29 @@ func TestValidateParameterDefinition(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateParameterDefinition_Source(t *testing.T) {
+	pd := ParameterDefinition{
+		Name: "myparam",
+		Schema: definition.Schema{
+			Type: "string",
+		},
+		Source: &ParameterSource{
+			Installation: "mysql",
+		},
+	}
+
+	err := pd.Validate()
+	assert.EqualError(t, err, `1 error occurred:
+	* no output specified for the source of parameter myparam
+
+`)
+
+	pd.Source.Output = "host"
+
+	err = pd.Validate()
+	assert.NoError(t, err)
+}
+
 func TestValidateOutputDefinition(t *testing.T) {
 	od := OutputDefinition{
 		Name: "myoutput",
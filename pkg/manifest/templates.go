@@ -0,0 +1,246 @@
+package manifest
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// templateTagPattern matches a mustache tag, for example {{ bundle.parameters.NAME }} or {{{ bundle.outputs.NAME }}}.
+var templateTagPattern = regexp.MustCompile(`{{({?)\s*([^{}]*?)\s*}?}}`)
+
+// templateValue is a value that can be referenced in a template. A value is either
+// a templateValues, a value without any fields, or a value with unknown fields.
+type templateValue interface{}
+
+// templateValues are the fields of a value that can be referenced in a template.
+type templateValues map[string]templateValue
+
+type templateField struct{}
+type templateUnknownFields struct{}
+
+var (
+	// fieldValue is a value without any fields, such as a string.
+	fieldValue templateValue = templateField{}
+
+	// unknownFieldsValue is a value whose fields are not known when the bundle is built,
+	// such as a dependency that has not been resolved.
+	unknownFieldsValue templateValue = templateUnknownFields{}
+)
+
+// ValidateTemplates checks that every template in the steps of each action only
+// references values that are defined by the bundle: its parameters, credentials,
+// outputs, images and the parameters and outputs of its dependencies. The outputs
+// of a step can be referenced by the steps that come after it in the same action.
+//
+// The dependency bundles are used to check the parameters and outputs of each
+// dependency. When a dependency's bundle is not specified, any parameter or
+// output of the dependency may be referenced.
+func (m *Manifest) ValidateTemplates(dependencies map[string]*bundle.Bundle) error {
+	var result error
+
+	for _, action := range m.getActionNames() {
+		steps := m.getActionSteps(action)
+		values := m.buildTemplateValues(Action(action), dependencies)
+		outputs := values["bundle"].(templateValues)["outputs"].(templateValues)
+
+		for _, step := range steps {
+			if step == nil {
+				continue
+			}
+
+			group := Steps{step}
+			if step.IsParallel() {
+				group = step.Parallel
+
+				// Only check the condition of the group, its steps are checked separately
+				description, _ := step.GetDescription()
+				for _, err := range validateTemplate(action, description, step.If, values) {
+					result = multierror.Append(result, err)
+				}
+			}
+
+			// The steps in a parallel step group can't use each other's outputs,
+			// the outputs are only available after the group completes
+			for _, s := range group {
+				if s == nil {
+					continue
+				}
+				description, _ := s.GetDescription()
+				data, err := yaml.Marshal(s)
+				if err != nil {
+					result = multierror.Append(result, errors.Wrapf(err, "invalid step data in the %s action step %q", action, description))
+					continue
+				}
+				for _, err := range validateTemplate(action, description, string(data), values) {
+					result = multierror.Append(result, err)
+				}
+			}
+			for _, s := range group {
+				if s == nil {
+					continue
+				}
+				for _, output := range s.GetOutputNames() {
+					outputs[output] = fieldValue
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// getActionNames returns the name of every action defined in the manifest.
+func (m *Manifest) getActionNames() []string {
+	actions := []string{string(ActionInstall), string(ActionUpgrade), string(ActionUninstall)}
+	custom := make([]string, 0, len(m.CustomActions))
+	for action := range m.CustomActions {
+		custom = append(custom, action)
+	}
+	sort.Strings(custom)
+	return append(actions, custom...)
+}
+
+func (m *Manifest) getActionSteps(action string) Steps {
+	switch Action(action) {
+	case ActionInstall:
+		return m.Install
+	case ActionUpgrade:
+		return m.Upgrade
+	case ActionUninstall:
+		return m.Uninstall
+	default:
+		return m.CustomActions[action]
+	}
+}
+
+// validateTemplate checks the references in the templates of a step.
+func validateTemplate(action string, description string, template string, values templateValues) []error {
+	var errs []error
+	checked := map[string]bool{}
+	for _, match := range templateTagPattern.FindAllStringSubmatch(template, -1) {
+		ref := match[2]
+		if match[1] == "" {
+			// Ignore closing sections, comments, partials and delimiter changes
+			if ref == "" || strings.ContainsAny(ref[:1], "/!>=") {
+				continue
+			}
+			ref = strings.TrimSpace(strings.TrimLeft(ref, "#^&"))
+		}
+
+		if ref == "." || checked[ref] {
+			continue
+		}
+		checked[ref] = true
+
+		if !isTemplateValueDefined(values, ref) {
+			errs = append(errs, errors.Errorf("the %s action step %q references %s, which is not defined", action, description, ref))
+		}
+	}
+	return errs
+}
+
+// isTemplateValueDefined determines if a reference to a value, for example bundle.parameters.NAME, is defined.
+func isTemplateValueDefined(values templateValues, ref string) bool {
+	var current templateValue = values
+	for _, part := range strings.Split(ref, ".") {
+		switch v := current.(type) {
+		case templateValues:
+			next, ok := v[part]
+			if !ok {
+				return false
+			}
+			current = next
+		case templateUnknownFields:
+			return true
+		default:
+			// A value without fields, such as a string
+			return false
+		}
+	}
+	return true
+}
+
+// buildTemplateValues returns the values that can be referenced in the templates of an action,
+// which mirror the data used to render the templates when the bundle is run. The outputs only
+// include the outputs of the bundle, the outputs of each step are added as the steps are checked.
+func (m *Manifest) buildTemplateValues(action Action, dependencies map[string]*bundle.Bundle) templateValues {
+	bun := templateValues{
+		"name":            fieldValue,
+		"version":         fieldValue,
+		"description":     fieldValue,
+		"invocationImage": fieldValue,
+	}
+
+	params := templateValues{}
+	for _, param := range m.Parameters {
+		params[param.Name] = fieldValue
+	}
+	bun["parameters"] = params
+
+	creds := templateValues{}
+	for _, cred := range m.Credentials {
+		creds[cred.Name] = fieldValue
+	}
+	bun["credentials"] = creds
+
+	outputs := templateValues{}
+	for _, output := range m.Outputs {
+		outputs[output.Name] = fieldValue
+	}
+	bun["outputs"] = outputs
+
+	deps := templateValues{}
+	for alias := range m.Dependencies {
+		dep := templateValues{
+			"name":        fieldValue,
+			"version":     fieldValue,
+			"description": fieldValue,
+			"parameters":  unknownFieldsValue,
+			"outputs":     unknownFieldsValue,
+		}
+
+		if depBun, ok := dependencies[alias]; ok && depBun != nil {
+			depParams := templateValues{}
+			for name := range depBun.Parameters {
+				depParams[name] = fieldValue
+			}
+			dep["parameters"] = depParams
+
+			// The outputs of dependencies are not available during uninstall, because the
+			// dependencies are uninstalled after the bundle
+			depOutputs := templateValues{}
+			if action != ActionUninstall {
+				for name, output := range depBun.Outputs {
+					if output.AppliesTo(string(action)) {
+						depOutputs[name] = fieldValue
+					}
+				}
+			}
+			dep["outputs"] = depOutputs
+		}
+
+		deps[alias] = dep
+	}
+	bun["dependencies"] = deps
+
+	// The fields of an image are named after the fields of MappedImage, in lowercase
+	imageFields := templateValues{}
+	imageType := reflect.TypeOf(MappedImage{})
+	for i := 0; i < imageType.NumField(); i++ {
+		imageFields[strings.ToLower(imageType.Field(i).Name)] = fieldValue
+	}
+	images := templateValues{}
+	for alias := range m.ImageMap {
+		images[alias] = imageFields
+	}
+	bun["images"] = images
+
+	return templateValues{"bundle": bun}
+}
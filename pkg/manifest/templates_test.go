@@ -0,0 +1,109 @@
+package manifest
+
+import (
+	"testing"
+
+	"get.porter.sh/porter/pkg/context"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTemplatesManifest(t *testing.T) *Manifest {
+	cxt := context.NewTestContext(t)
+	cxt.AddTestFile("testdata/templates.porter.yaml", "porter.yaml")
+
+	m, err := LoadManifestFrom(cxt.Context, "porter.yaml")
+	require.NoError(t, err)
+	return m
+}
+
+func newExecStep(description string, args ...interface{}) *Step {
+	return &Step{
+		Data: map[string]interface{}{
+			"exec": map[interface{}]interface{}{
+				"description": description,
+				"command":     "./test.sh",
+				"arguments":   args,
+			},
+		},
+	}
+}
+
+func TestManifest_ValidateTemplates(t *testing.T) {
+	m := loadTemplatesManifest(t)
+
+	err := m.ValidateTemplates(nil)
+	require.NoError(t, err)
+}
+
+func TestManifest_ValidateTemplates_UndefinedReferences(t *testing.T) {
+	m := loadTemplatesManifest(t)
+	m.Install = append(m.Install, newExecStep("Typo", "{{ bundle.parameter.database }}", "{{bundle.credentials.missing}}"))
+	m.Upgrade = Steps{
+		newExecStep("Use an install output", "{{ bundle.outputs.user }}"),
+		newExecStep("Use a field of a value", "{{ bundle.name.first }}"),
+	}
+
+	err := m.ValidateTemplates(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `the install action step "Typo" references bundle.parameter.database, which is not defined`)
+	assert.Contains(t, err.Error(), `the install action step "Typo" references bundle.credentials.missing, which is not defined`)
+	assert.Contains(t, err.Error(), `the upgrade action step "Use an install output" references bundle.outputs.user, which is not defined`)
+	assert.Contains(t, err.Error(), `the upgrade action step "Use a field of a value" references bundle.name.first, which is not defined`)
+}
+
+func TestManifest_ValidateTemplates_Parallel(t *testing.T) {
+	m := loadTemplatesManifest(t)
+
+	// A step in a parallel group can't use the outputs of the other steps in the group
+	group := m.Install[1]
+	group.Parallel[0] = newExecStep("Grant access", "{{ bundle.outputs.rows }}")
+	group.If = "{{ bundle.outputs.missing }}"
+
+	err := m.ValidateTemplates(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `the install action step "Grant access" references bundle.outputs.rows, which is not defined`)
+	assert.Contains(t, err.Error(), `the install action step "Run in parallel: Grant access, Seed data" references bundle.outputs.missing, which is not defined`)
+}
+
+func TestManifest_ValidateTemplates_Dependencies(t *testing.T) {
+	mysql := &bundle.Bundle{
+		Parameters: map[string]bundle.Parameter{"password": {}},
+		Outputs: map[string]bundle.Output{
+			"host":     {ApplyTo: []string{"install"}},
+			"username": {},
+		},
+	}
+
+	t.Run("defined", func(t *testing.T) {
+		m := loadTemplatesManifest(t)
+
+		err := m.ValidateTemplates(map[string]*bundle.Bundle{"mysql": mysql})
+		require.NoError(t, err)
+	})
+
+	t.Run("undefined", func(t *testing.T) {
+		m := loadTemplatesManifest(t)
+		m.Install = append(m.Install, newExecStep("Connect", "{{ bundle.dependencies.mysql.outputs.port }}", "{{ bundle.dependencies.mysql.parameters.password }}"))
+		m.Upgrade = Steps{newExecStep("Reconnect", "{{ bundle.dependencies.mysql.outputs.host }}")}
+		m.Uninstall = append(m.Uninstall, newExecStep("Disconnect", "{{ bundle.dependencies.mysql.outputs.username }}"))
+
+		err := m.ValidateTemplates(map[string]*bundle.Bundle{"mysql": mysql})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `the install action step "Connect" references bundle.dependencies.mysql.outputs.port, which is not defined`)
+		assert.NotContains(t, err.Error(), "bundle.dependencies.mysql.parameters.password")
+		assert.Contains(t, err.Error(), `the upgrade action step "Reconnect" references bundle.dependencies.mysql.outputs.host, which is not defined`)
+		assert.Contains(t, err.Error(), `the uninstall action step "Disconnect" references bundle.dependencies.mysql.outputs.username, which is not defined`)
+	})
+
+	t.Run("unresolved", func(t *testing.T) {
+		m := loadTemplatesManifest(t)
+		m.Install = append(m.Install, newExecStep("Connect", "{{ bundle.dependencies.mysql.outputs.port }}", "{{ bundle.dependencies.postgres.outputs.port }}"))
+
+		err := m.ValidateTemplates(nil)
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "bundle.dependencies.mysql.outputs.port")
+		assert.Contains(t, err.Error(), `the install action step "Connect" references bundle.dependencies.postgres.outputs.port, which is not defined`)
+	})
+}
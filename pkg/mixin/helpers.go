@@ -3,13 +3,20 @@ package mixin
 import (
 	"fmt"
 	"io/ioutil"
+	"sync"
 
 	"get.porter.sh/porter/pkg/context"
+	"github.com/pkg/errors"
 )
 
 // TestMixinProvider helps us test Porter.Mixins in our unit tests without actually hitting any real mixins on the file system.
 type TestMixinProvider struct {
 	RunAssertions []func(mixinCxt *context.Context, mixinName string, commandOpts CommandOptions)
+
+	// RunErrors are returned, in order, by each call to Run. Run succeeds after they are used up.
+	RunErrors []error
+
+	lock sync.Mutex
 }
 
 func (p *TestMixinProvider) List() ([]Metadata, error) {
@@ -44,6 +51,19 @@ func (p *TestMixinProvider) Run(mixinCxt *context.Context, mixinName string, com
 	for _, assert := range p.RunAssertions {
 		assert(mixinCxt, mixinName, commandOpts)
 	}
+	// Like the mixin runner, fail the command when it was cancelled while it ran
+	select {
+	case <-commandOpts.Cancel:
+		return errors.New("mixin command was cancelled")
+	default:
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.RunErrors) > 0 {
+		err := p.RunErrors[0]
+		p.RunErrors = p.RunErrors[1:]
+		return err
+	}
 	if commandOpts.Command == "build" {
 		fmt.Fprintln(mixinCxt.Out, "# exec mixin has no buildtime dependencies")
 	}
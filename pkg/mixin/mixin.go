@@ -1,6 +1,8 @@
 package mixin
 
 import (
+	"time"
+
 	"get.porter.sh/porter/pkg/context"
 )
 
@@ -37,4 +39,10 @@ type CommandOptions struct {
 	Command string
 	Input   string
 	File    string
+
+	// Timeout is how long the mixin may run before it is stopped. There is no timeout when it is 0.
+	Timeout time.Duration
+
+	// Cancel stops the mixin when it is closed.
+	Cancel <-chan struct{}
 }
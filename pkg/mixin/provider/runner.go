@@ -5,6 +5,7 @@ import (
 	"io"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"get.porter.sh/porter/pkg/context"
 	"get.porter.sh/porter/pkg/mixin"
@@ -98,12 +99,42 @@ func (r *Runner) Run(commandOpts mixin.CommandOptions) error {
 		fmt.Fprintln(r.Err, prettyCmd)
 	}
 
+	stoppable := commandOpts.Timeout > 0 || commandOpts.Cancel != nil
+	if stoppable {
+		startProcessGroup(cmd)
+	}
+
 	err := cmd.Start()
 	if err != nil {
 		return errors.Wrapf(err, "could not run mixin command %s", prettyCmd)
 	}
 
-	return cmd.Wait()
+	if !stoppable {
+		return cmd.Wait()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var timeout <-chan time.Time
+	if commandOpts.Timeout > 0 {
+		timeout = time.After(commandOpts.Timeout)
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-timeout:
+		killProcessGroup(cmd)
+		<-done
+		return errors.Errorf("mixin command %s timed out after %s", prettyCmd, commandOpts.Timeout)
+	case <-commandOpts.Cancel:
+		killProcessGroup(cmd)
+		<-done
+		return errors.Errorf("mixin command %s was cancelled", prettyCmd)
+	}
 }
 
 func (r *Runner) getMixinPath() string {
@@ -0,0 +1,21 @@
+// +build !windows
+
+package mixinprovider
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// startProcessGroup runs the mixin in its own process group, so that the
+// processes that the mixin starts can be stopped along with it.
+func startProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the mixin and every process that it started. Otherwise
+// a process started by the mixin keeps its output open, and waiting for the
+// mixin blocks until that process exits.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,47 @@
+// +build !windows
+
+package mixinprovider
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/mixin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSlowTestRunner creates a runner for a mixin that starts a process that
+// outlives the mixin, and keeps the output of the mixin open.
+func newSlowTestRunner(t *testing.T) *TestRunner {
+	r := NewTestRunner(t, "exec", true)
+	r.Debug = false
+	r.NewCommand = func(command string, args ...string) *exec.Cmd {
+		return exec.Command("sh", "-c", "sleep 5 & wait")
+	}
+	return r
+}
+
+func TestRunner_Run_Timeout(t *testing.T) {
+	r := newSlowTestRunner(t)
+
+	start := time.Now()
+	err := r.Run(mixin.CommandOptions{Command: "install", Timeout: 200 * time.Millisecond})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out after 200ms")
+	assert.Less(t, int64(time.Since(start)), int64(2*time.Second), "the processes started by the mixin should be killed when it times out")
+}
+
+func TestRunner_Run_Cancel(t *testing.T) {
+	r := newSlowTestRunner(t)
+
+	cancel := make(chan struct{})
+	time.AfterFunc(200*time.Millisecond, func() { close(cancel) })
+
+	start := time.Now()
+	err := r.Run(mixin.CommandOptions{Command: "install", Cancel: cancel})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was cancelled")
+	assert.Less(t, int64(time.Since(start)), int64(2*time.Second), "the processes started by the mixin should be killed when it is cancelled")
+}
@@ -0,0 +1,16 @@
+// +build windows
+
+package mixinprovider
+
+import (
+	"os/exec"
+)
+
+// startProcessGroup is not supported on Windows, the mixin is run as usual.
+func startProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the mixin. The processes that the mixin started are not
+// killed on Windows.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,87 @@
+package parameters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/pkg/errors"
+)
+
+// ConvertValue converts the string value of a parameter to the type of its definition.
+// The values of object and array parameters are specified as JSON.
+func ConvertValue(def *definition.Schema, rawValue string) (interface{}, error) {
+	switch def.Type {
+	case "object":
+		var value map[string]interface{}
+		if err := json.Unmarshal([]byte(rawValue), &value); err != nil || value == nil {
+			return nil, errors.Errorf("%q is not a valid JSON object", rawValue)
+		}
+		return value, nil
+	case "array":
+		var value []interface{}
+		if err := json.Unmarshal([]byte(rawValue), &value); err != nil || value == nil {
+			return nil, errors.Errorf("%q is not a valid JSON array", rawValue)
+		}
+		return value, nil
+	case "number":
+		value, err := strconv.ParseFloat(rawValue, 64)
+		if err != nil {
+			return nil, errors.Errorf("%q is not a valid number", rawValue)
+		}
+		return value, nil
+	case "integer":
+		value, err := strconv.Atoi(rawValue)
+		if err != nil {
+			return nil, errors.Errorf("%q is not a valid integer", rawValue)
+		}
+		return value, nil
+	default:
+		return def.ConvertValue(rawValue)
+	}
+}
+
+// ValidateValue converts the string value of a parameter to the type of its
+// definition, and validates it against the schema of the definition, for example
+// that it is one of the allowed values or within the allowed range.
+func ValidateValue(def *definition.Schema, rawValue string) error {
+	value, err := ConvertValue(def, rawValue)
+	if err != nil {
+		return err
+	}
+
+	valErrs, err := def.Validate(value)
+	if err != nil {
+		return errors.Wrap(err, "unable to validate the value against the parameter definition")
+	}
+	if len(valErrs) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(valErrs))
+	for i, valErr := range valErrs {
+		msgs[i] = valErr.Error
+	}
+	return errors.New(strings.Join(msgs, ", "))
+}
+
+// FormatValue formats the value of a parameter, such as its default value, in the format
+// used to specify the value of the parameter, e.g. with --param.
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,67 @@
+package parameters
+
+import (
+	"testing"
+
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertValue(t *testing.T) {
+	testcases := []struct {
+		name      string
+		schema    definition.Schema
+		value     string
+		want      interface{}
+		wantError string
+	}{
+		{name: "string", schema: definition.Schema{Type: "string"}, value: "eastus", want: "eastus"},
+		{name: "integer", schema: definition.Schema{Type: "integer"}, value: "3", want: 3},
+		{name: "invalid integer", schema: definition.Schema{Type: "integer"}, value: "three", wantError: `"three" is not a valid integer`},
+		{name: "number", schema: definition.Schema{Type: "number"}, value: "1.5", want: 1.5},
+		{name: "invalid number", schema: definition.Schema{Type: "number"}, value: "abc", wantError: `"abc" is not a valid number`},
+		{name: "boolean", schema: definition.Schema{Type: "boolean"}, value: "true", want: true},
+		{name: "object", schema: definition.Schema{Type: "object"}, value: `{"size":"small"}`, want: map[string]interface{}{"size": "small"}},
+		{name: "invalid object", schema: definition.Schema{Type: "object"}, value: "small", wantError: `"small" is not a valid JSON object`},
+		{name: "array", schema: definition.Schema{Type: "array"}, value: `["a","b"]`, want: []interface{}{"a", "b"}},
+		{name: "invalid array", schema: definition.Schema{Type: "array"}, value: "a,b", wantError: `"a,b" is not a valid JSON array`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ConvertValue(&tc.schema, tc.value)
+			if tc.wantError != "" {
+				require.EqualError(t, err, tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	min := 1
+	def := &definition.Schema{
+		Type:    "integer",
+		Minimum: &min,
+	}
+
+	err := ValidateValue(def, "2")
+	require.NoError(t, err)
+
+	err = ValidateValue(def, "0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "greater than or equal to 1")
+
+	err = ValidateValue(def, "two")
+	assert.EqualError(t, err, `"two" is not a valid integer`)
+
+	enum := &definition.Schema{
+		Type: "string",
+		Enum: []interface{}{"small", "large"},
+	}
+	err = ValidateValue(enum, "medium")
+	assert.EqualError(t, err, `should be one of ["small", "large"]`)
+}
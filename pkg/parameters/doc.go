@@ -1 +1,9 @@
+// Package parameters provides primitives for working with bundle parameters
+// specified on the command line and Porter parameter sets.
+//
+// Parameter Sets define mappings from a parameter of a bundle to where to
+// look for its value when the bundle is run, such as an environment variable,
+// a file, a command, a secret or a specific value. This allows a group of
+// parameters, for example the parameters for an environment, to be saved
+// and referenced by name instead of being repeated on every command.
 package parameters // import "get.porter.sh/porter/pkg/parameters"
@@ -0,0 +1,126 @@
+package parameters
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ParseParameterFile parses the parameters defined in a file. JSON (.json) and
+// YAML (.yaml, .yml) files contain a map of parameter names to values, and any other
+// file contains lines in the form NAME=VALUE.
+//
+// Values that are not strings are converted to their string representation, objects and
+// arrays are converted to JSON, so that they can be converted to the type of the parameter
+// when the bundle is executed.
+func ParseParameterFile(path string, data []byte) (map[string]string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		var values map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&values); err != nil {
+			return nil, errors.Wrapf(err, "could not parse the JSON param file %s", path)
+		}
+		return formatParameterValues(path, values)
+	case ".yaml", ".yml":
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return nil, errors.Wrapf(err, "could not parse the YAML param file %s", path)
+		}
+		return formatParameterValues(path, values)
+	default:
+		var lines []string
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, errors.Wrapf(err, "unable to read contents of param file %s", path)
+		}
+
+		return ParseVariableAssignments(lines)
+	}
+}
+
+// formatParameterValues converts the values parsed from a parameter file to strings.
+func formatParameterValues(path string, values map[string]interface{}) (map[string]string, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []string
+	params := make(map[string]string, len(values))
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			errs = append(errs, "a parameter name is required")
+			continue
+		}
+
+		value, err := formatParameterValue(values[name])
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid value for parameter %s: %s", name, err))
+			continue
+		}
+		params[name] = value
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Errorf("invalid param file %s:\n  * %s", path, strings.Join(errs, "\n  * "))
+	}
+	return params, nil
+}
+
+func formatParameterValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case map[interface{}]interface{}, map[string]interface{}, []interface{}:
+		data, err := json.Marshal(ToJSONCompatible(v))
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	default:
+		return fmt.Sprintf("%v", v), nil
+	}
+}
+
+// ToJSONCompatible converts the maps in a value unmarshaled from YAML, which have keys
+// of any type, to maps with string keys so that the value can be marshaled to JSON or
+// validated against a JSON schema. The value is copied, not modified.
+func ToJSONCompatible(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for key, value := range t {
+			m[fmt.Sprintf("%v", key)] = ToJSONCompatible(value)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for key, value := range t {
+			m[key] = ToJSONCompatible(value)
+		}
+		return m
+	case []interface{}:
+		items := make([]interface{}, len(t))
+		for i, value := range t {
+			items[i] = ToJSONCompatible(value)
+		}
+		return items
+	default:
+		return v
+	}
+}
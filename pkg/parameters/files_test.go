@@ -0,0 +1,59 @@
+package parameters
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseParameterFile(t *testing.T) {
+	wantParams := map[string]string{
+		"region":   "eastus",
+		"replicas": "3",
+		"debug":    "true",
+		"ratio":    "0.5",
+		"empty":    "",
+		"tags":     `["web","prod"]`,
+		"config":   `{"logging":{"level":"info"},"motd":"Welcome to the app!\nHave a nice day.\n"}`,
+	}
+
+	for _, path := range []string{"testdata/params.yaml", "testdata/params.json"} {
+		t.Run(path, func(t *testing.T) {
+			data, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+
+			params, err := ParseParameterFile(path, data)
+			require.NoError(t, err)
+			assert.Equal(t, wantParams, params)
+		})
+	}
+}
+
+func TestParseParameterFile_Assignments(t *testing.T) {
+	params, err := ParseParameterFile("params.txt", []byte("a=b\nc={\"d\": 1}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "b", "c": `{"d": 1}`}, params)
+}
+
+func TestParseParameterFile_Invalid(t *testing.T) {
+	testcases := []struct {
+		name      string
+		path      string
+		data      string
+		wantError string
+	}{
+		{"yaml", "params.yml", "- a\n- b\n", "could not parse the YAML param file params.yml"},
+		{"json", "params.json", `{"a": `, "could not parse the JSON param file params.json"},
+		{"assignments", "params.txt", "a", "invalid parameter (a), must be in name=value format"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseParameterFile(tc.path, []byte(tc.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantError)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package parameters
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/credentials"
+	"github.com/cnabio/cnab-go/secrets/host"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+)
+
+// GenerateOptions are the options to generate a parameter set.
+type GenerateOptions struct {
+	// Name of the parameter set.
+	Name string
+
+	// Parameters from the bundle.
+	Parameters map[string]bundle.Parameter
+
+	// Silent generates the parameter set without asking for the source of each parameter.
+	Silent bool
+}
+
+const (
+	questionSecret  = "secret"
+	questionValue   = "specific value"
+	questionEnvVar  = "environment variable"
+	questionPath    = "file path"
+	questionCommand = "shell command"
+)
+
+type parameterGenerator func(name string) (ParameterStrategy, error)
+
+// GenerateParameters generates a parameter set based on the given options.
+func GenerateParameters(opts GenerateOptions) (*ParameterSet, error) {
+	if opts.Name == "" {
+		return nil, errors.New("parameter set name is required")
+	}
+	generator := genParameterSurvey
+	if opts.Silent {
+		generator = genEmptyParameters
+	}
+	pset, err := genParameterSet(opts.Name, opts.Parameters, generator)
+	if err != nil {
+		return nil, err
+	}
+	return &pset, nil
+}
+
+func genParameterSet(name string, params map[string]bundle.Parameter, fn parameterGenerator) (ParameterSet, error) {
+	pset := ParameterSet{
+		Name:       name,
+		Parameters: []ParameterStrategy{},
+	}
+
+	if strings.ContainsAny(name, "./\\") {
+		return pset, fmt.Errorf("parameter set name '%s' cannot contain the following characters: './\\'", name)
+	}
+
+	var paramNames []string
+	for name := range params {
+		// Skip the parameters that Porter adds to every bundle
+		if strings.HasPrefix(name, "porter-") {
+			continue
+		}
+		paramNames = append(paramNames, name)
+	}
+
+	sort.Strings(paramNames)
+
+	for _, name := range paramNames {
+		p, err := fn(name)
+		if err != nil {
+			return pset, err
+		}
+		pset.Parameters = append(pset.Parameters, p)
+	}
+
+	return pset, nil
+}
+
+func genEmptyParameters(name string) (ParameterStrategy, error) {
+	return ParameterStrategy{
+		Name:   name,
+		Source: credentials.Source{Value: "TODO"},
+	}, nil
+}
+
+func genParameterSurvey(name string) (ParameterStrategy, error) {
+	sourceTypePrompt := &survey.Select{
+		Message: fmt.Sprintf("How would you like to set parameter %q", name),
+		Options: []string{questionSecret, questionValue, questionEnvVar, questionPath, questionCommand},
+		Default: questionValue,
+	}
+
+	p := ParameterStrategy{Name: name}
+
+	source := ""
+	if err := survey.AskOne(sourceTypePrompt, &source, nil); err != nil {
+		return p, err
+	}
+
+	var sourceKey, sourceName string
+	switch source {
+	case questionSecret:
+		sourceKey, sourceName = "secret", "secret"
+	case questionValue:
+		sourceKey, sourceName = host.SourceValue, "value"
+	case questionEnvVar:
+		sourceKey, sourceName = host.SourceEnv, "environment variable"
+	case questionPath:
+		sourceKey, sourceName = host.SourcePath, "path"
+	case questionCommand:
+		sourceKey, sourceName = host.SourceCommand, "command"
+	}
+
+	sourceValuePrompt := &survey.Input{
+		Message: fmt.Sprintf("Enter the %s that will be used to set parameter %q", sourceName, name),
+	}
+
+	value := ""
+	if err := survey.AskOne(sourceValuePrompt, &value, nil); err != nil {
+		return p, err
+	}
+
+	p.Source.Key = sourceKey
+	p.Source.Value = value
+	return p, nil
+}
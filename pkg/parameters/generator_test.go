@@ -0,0 +1,43 @@
+package parameters
+
+import (
+	"testing"
+
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateParameters(t *testing.T) {
+	opts := GenerateOptions{
+		Name:   "dev",
+		Silent: true,
+		Parameters: map[string]bundle.Parameter{
+			"replicas":     {Definition: "replicas"},
+			"region":       {Definition: "region"},
+			"porter-debug": {Definition: "porter-debug"},
+		},
+	}
+
+	ps, err := GenerateParameters(opts)
+	require.NoError(t, err)
+	require.Len(t, ps.Parameters, 2, "the parameters defined by porter should not be included")
+	assert.Equal(t, "region", ps.Parameters[0].Name)
+	assert.Equal(t, "replicas", ps.Parameters[1].Name)
+}
+
+func TestGenerateParameters_BadName(t *testing.T) {
+	opts := GenerateOptions{
+		Name:   "this.hasadot",
+		Silent: true,
+	}
+
+	ps, err := GenerateParameters(opts)
+	require.Nil(t, ps)
+	require.EqualError(t, err, "parameter set name 'this.hasadot' cannot contain the following characters: './\\'")
+}
+
+func TestGenerateParameters_NoName(t *testing.T) {
+	_, err := GenerateParameters(GenerateOptions{Silent: true})
+	require.EqualError(t, err, "parameter set name is required")
+}
@@ -0,0 +1,45 @@
+package parameters
+
+import (
+	"testing"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/secrets"
+	inmemorysecrets "get.porter.sh/porter/pkg/secrets/in-memory"
+	inmemorystorage "get.porter.sh/porter/pkg/storage/in-memory"
+	"github.com/pkg/errors"
+)
+
+var _ ParameterProvider = &TestParameterProvider{}
+
+type TestParameterProvider struct {
+	T          *testing.T
+	TestConfig *config.TestConfig
+	*ParameterStorage
+}
+
+func NewTestParameterProvider(t *testing.T, tc *config.TestConfig) TestParameterProvider {
+	backingSecrets := inmemorysecrets.NewStore()
+	backingParams := inmemorystorage.NewStore()
+	paramStore := NewParameterStore(backingParams)
+	return TestParameterProvider{
+		T:          t,
+		TestConfig: tc,
+		ParameterStorage: &ParameterStorage{
+			ParametersStore: &paramStore,
+			SecretsStore:    secrets.NewSecretStore(backingSecrets),
+		},
+	}
+}
+
+func (p *TestParameterProvider) AddTestParameters(path string) {
+	ps, err := Load(path)
+	if err != nil {
+		p.T.Fatal(errors.Wrapf(err, "could not read test parameters from %s", path))
+	}
+
+	err = p.ParameterStorage.Save(*ps)
+	if err != nil {
+		p.T.Fatal(errors.Wrap(err, "could not load test parameters into in memory parameter storage"))
+	}
+}
@@ -0,0 +1,16 @@
+package parameters
+
+// ParameterProvider interface for managing sets of parameters.
+type ParameterProvider interface {
+	ParameterStore
+	ResolveAll(params ParameterSet) (map[string]string, error)
+}
+
+// ParameterStore is an interface representing the storage of parameter sets.
+type ParameterStore interface {
+	List() ([]string, error)
+	Save(ParameterSet) error
+	Read(name string) (ParameterSet, error)
+	ReadAll() ([]ParameterSet, error)
+	Delete(name string) error
+}
@@ -0,0 +1,50 @@
+package parameters
+
+import (
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/secrets"
+	secretplugins "get.porter.sh/porter/pkg/secrets/pluginstore"
+	crudplugins "get.porter.sh/porter/pkg/storage/pluginstore"
+	cnabsecrets "github.com/cnabio/cnab-go/secrets"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+type ParametersStore = Store
+type SecretsStore = cnabsecrets.Store
+
+var _ ParameterProvider = &ParameterStorage{}
+
+// ParameterStorage provides access to parameter sets by instantiating plugins that
+// implement CRUD storage.
+type ParameterStorage struct {
+	*config.Config
+	*ParametersStore
+	SecretsStore
+}
+
+func NewParameterStorage(c *config.Config, storagePlugin *crudplugins.Store) *ParameterStorage {
+	paramStore := NewParameterStore(storagePlugin)
+	return &ParameterStorage{
+		Config:          c,
+		ParametersStore: &paramStore,
+		SecretsStore:    secrets.NewSecretStore(secretplugins.NewStore(c)),
+	}
+}
+
+// ResolveAll resolves the value of each parameter in the set from its source.
+func (s ParameterStorage) ResolveAll(params ParameterSet) (map[string]string, error) {
+	resolvedParams := make(map[string]string)
+	var resolveErrors error
+
+	for _, param := range params.Parameters {
+		value, err := s.Resolve(param.Source.Key, param.Source.Value)
+		if err != nil {
+			resolveErrors = multierror.Append(resolveErrors, errors.Wrapf(err, "unable to resolve parameter %s.%s from %s %s", params.Name, param.Name, param.Source.Key, param.Source.Value))
+		}
+
+		resolvedParams[param.Name] = value
+	}
+
+	return resolvedParams, resolveErrors
+}
@@ -0,0 +1,50 @@
+package parameters
+
+import (
+	"os"
+	"testing"
+
+	"get.porter.sh/porter/pkg/secrets"
+	"github.com/cnabio/cnab-go/credentials"
+	"github.com/cnabio/cnab-go/secrets/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParameterStorage_ResolveAll(t *testing.T) {
+	s := ParameterStorage{
+		SecretsStore: secrets.NewSecretStore(&host.SecretStore{}),
+	}
+
+	os.Setenv("DEV_PASSWORD", "s3cr3t")
+	defer os.Unsetenv("DEV_PASSWORD")
+
+	ps := ParameterSet{
+		Name: "dev",
+		Parameters: []ParameterStrategy{
+			{Name: "region", Source: credentials.Source{Key: host.SourceValue, Value: "eastus"}},
+			{Name: "password", Source: credentials.Source{Key: host.SourceEnv, Value: "DEV_PASSWORD"}},
+		},
+	}
+
+	params, err := s.ResolveAll(ps)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"region": "eastus", "password": "s3cr3t"}, params)
+}
+
+func TestParameterStorage_ResolveAll_Error(t *testing.T) {
+	s := ParameterStorage{
+		SecretsStore: secrets.NewSecretStore(&host.SecretStore{}),
+	}
+
+	ps := ParameterSet{
+		Name: "dev",
+		Parameters: []ParameterStrategy{
+			{Name: "region", Source: credentials.Source{Value: "TODO"}},
+		},
+	}
+
+	_, err := s.ResolveAll(ps)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to resolve parameter dev.region")
+}
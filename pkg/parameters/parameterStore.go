@@ -0,0 +1,81 @@
+package parameters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cnabio/cnab-go/utils/crud"
+)
+
+// ItemType is the type of item stored for parameter sets.
+const ItemType = "parameters"
+
+// ErrNotFound represents a parameter set not found in storage
+var ErrNotFound = errors.New("Parameter set does not exist")
+
+// Store is a persistent store for parameter sets.
+type Store struct {
+	backingStore *crud.BackingStore
+}
+
+// NewParameterStore creates a persistent store for parameter sets using the specified
+// backing key-blob store.
+func NewParameterStore(store crud.Store) Store {
+	return Store{
+		backingStore: crud.NewBackingStore(store),
+	}
+}
+
+// List lists the names of the stored parameter sets.
+func (s Store) List() ([]string, error) {
+	return s.backingStore.List(ItemType)
+}
+
+// Save a parameter set. Any previous version of the parameter set is overwritten.
+func (s Store) Save(pset ParameterSet) error {
+	bytes, err := json.MarshalIndent(pset, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.backingStore.Save(ItemType, pset.Name, bytes)
+}
+
+// Read loads the parameter set with the given name from the store.
+func (s Store) Read(name string) (ParameterSet, error) {
+	bytes, err := s.backingStore.Read(ItemType, name)
+	if err != nil {
+		if err == crud.ErrRecordDoesNotExist {
+			return ParameterSet{}, ErrNotFound
+		}
+		return ParameterSet{}, err
+	}
+	pset := ParameterSet{}
+	err = json.Unmarshal(bytes, &pset)
+	return pset, err
+}
+
+// ReadAll retrieves all the parameter sets.
+func (s Store) ReadAll() ([]ParameterSet, error) {
+	results, err := s.backingStore.ReadAll(ItemType)
+	if err != nil {
+		return nil, err
+	}
+
+	psets := make([]ParameterSet, len(results))
+	for i := range psets {
+		pset := ParameterSet{}
+		err = json.Unmarshal(results[i], &pset)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshaling parameter set: %v", err)
+		}
+		psets[i] = pset
+	}
+
+	return psets, nil
+}
+
+// Delete deletes a parameter set from the store.
+func (s Store) Delete(name string) error {
+	return s.backingStore.Delete(ItemType, name)
+}
@@ -0,0 +1,44 @@
+package parameters
+
+import (
+	"testing"
+
+	inmemorystorage "get.porter.sh/porter/pkg/storage/in-memory"
+	"github.com/cnabio/cnab-go/credentials"
+	"github.com/cnabio/cnab-go/secrets/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	s := NewParameterStore(inmemorystorage.NewStore())
+
+	_, err := s.Read("dev")
+	assert.Equal(t, ErrNotFound, err)
+
+	dev := ParameterSet{
+		Name: "dev",
+		Parameters: []ParameterStrategy{
+			{Name: "region", Source: credentials.Source{Key: host.SourceValue, Value: "eastus"}},
+			{Name: "password", Source: credentials.Source{Key: host.SourceEnv, Value: "DEV_PASSWORD"}},
+		},
+	}
+	require.NoError(t, s.Save(dev))
+	require.NoError(t, s.Save(ParameterSet{Name: "prod"}))
+
+	got, err := s.Read("dev")
+	require.NoError(t, err)
+	assert.Equal(t, dev.Parameters, got.Parameters)
+
+	names, err := s.List()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"dev", "prod"}, names)
+
+	all, err := s.ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	require.NoError(t, s.Delete("dev"))
+	_, err = s.Read("dev")
+	assert.Equal(t, ErrNotFound, err)
+}
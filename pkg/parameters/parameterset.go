@@ -0,0 +1,43 @@
+package parameters
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/cnabio/cnab-go/credentials"
+	"gopkg.in/yaml.v2"
+)
+
+// ParameterSet represents a collection of parameters and where to find their values.
+type ParameterSet struct {
+	// Name is the name of the parameter set.
+	Name string `json:"name" yaml:"name"`
+	// Created timestamp of the parameter set.
+	Created time.Time `json:"created" yaml:"created"`
+	// Modified timestamp of the parameter set.
+	Modified time.Time `json:"modified" yaml:"modified"`
+	// Parameters is a list of parameter specs.
+	Parameters []ParameterStrategy `json:"parameters" yaml:"parameters"`
+}
+
+// ParameterStrategy represents the source of the value of a parameter.
+type ParameterStrategy struct {
+	// Name is the name of the parameter.
+	// Name is used to match a parameter strategy to a bundle's parameter.
+	Name string `json:"name" yaml:"name"`
+	// Source is the location of the parameter value, it supports the
+	// same sources as credentials, for example env, path, command, secret and value.
+	Source credentials.Source `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+// Load a ParameterSet from a file at a given path.
+//
+// It does not resolve the values of the parameters.
+func Load(path string) (*ParameterSet, error) {
+	pset := &ParameterSet{}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return pset, err
+	}
+	return pset, yaml.Unmarshal(data, pset)
+}
@@ -0,0 +1,175 @@
+package parameters
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"get.porter.sh/porter/pkg/cnab/extensions"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/pkg/errors"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+)
+
+// AskFunc asks the user a question and stores the answer in response, see survey.AskOne.
+type AskFunc func(prompt survey.Prompt, response interface{}, validator survey.Validator) error
+
+// PromptOptions are the options to prompt for the parameters of a bundle.
+type PromptOptions struct {
+	// Bundle that defines the parameters.
+	Bundle *bundle.Bundle
+
+	// Action that is executed, only the parameters that apply to the action are prompted.
+	Action string
+
+	// Specified are the parameters that already have a value, and are not prompted.
+	Specified map[string]string
+
+	// Ask the user a question. Defaults to survey.AskOne.
+	Ask AskFunc
+}
+
+// PromptForParameters asks the user for the value of each parameter of the bundle that
+// applies to the action and was not specified. Parameters that default to an output
+// of an installation, and the parameters that Porter adds to every bundle, are not
+// prompted. Each value is validated against the parameter's definition.
+//
+// Returns the values that were entered. When the default value of a parameter is
+// accepted, the parameter is not included so that the default is used.
+func PromptForParameters(opts PromptOptions) (map[string]string, error) {
+	ask := opts.Ask
+	if ask == nil {
+		ask = func(prompt survey.Prompt, response interface{}, validator survey.Validator) error {
+			return survey.AskOne(prompt, response, validator)
+		}
+	}
+
+	bun := opts.Bundle
+	sources, err := extensions.ReadParameterSources(bun)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(bun.Parameters))
+	for name, param := range bun.Parameters {
+		if _, ok := opts.Specified[name]; ok {
+			continue
+		}
+		if _, ok := sources[name]; ok {
+			continue
+		}
+		if strings.HasPrefix(name, "porter-") || !param.AppliesTo(opts.Action) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		param := bun.Parameters[name]
+		def, ok := bun.Definitions[param.Definition]
+		if !ok {
+			return nil, errors.Errorf("definition %s not defined in bundle", param.Definition)
+		}
+
+		value, err := promptForParameter(ask, name, param, def)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not prompt for parameter %s", name)
+		}
+
+		if def.Default != nil && value == FormatValue(def.Default) {
+			continue
+		}
+		if value == "" && def.Default == nil && !param.Required {
+			continue
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// promptForParameter asks the user for the value of a parameter, using a prompt that
+// matches its definition.
+func promptForParameter(ask AskFunc, name string, param bundle.Parameter, def *definition.Schema) (string, error) {
+	description := param.Description
+	if description == "" {
+		description = def.Description
+	}
+
+	message := fmt.Sprintf("Enter a value for parameter %q", name)
+	if description != "" {
+		message = fmt.Sprintf("%s (%s)", message, description)
+	}
+
+	hasDefault := def.Default != nil
+	defaultValue := FormatValue(def.Default)
+	validator := func(ans interface{}) error {
+		value := fmt.Sprintf("%v", ans)
+		if value == "" {
+			if param.Required && !hasDefault {
+				return errors.New("a value is required")
+			}
+			return nil
+		}
+		return ValidateValue(def, value)
+	}
+
+	switch {
+	case def.WriteOnly != nil && *def.WriteOnly:
+		// Don't show the default value of a sensitive parameter
+		help := ""
+		if hasDefault {
+			help = "Leave empty to use the default value"
+		}
+		prompt := &survey.Password{
+			Message: message,
+			Help:    help,
+		}
+
+		var value string
+		err := ask(prompt, &value, validator)
+		if value == "" && hasDefault {
+			value = defaultValue
+		}
+		return value, err
+	case len(def.Enum) > 0:
+		prompt := &survey.Select{
+			Message: message,
+			Options: make([]string, len(def.Enum)),
+		}
+		for i, option := range def.Enum {
+			prompt.Options[i] = FormatValue(option)
+		}
+		if hasDefault {
+			prompt.Default = defaultValue
+		}
+
+		var value string
+		err := ask(prompt, &value, validator)
+		return value, err
+	case def.Type == "boolean":
+		prompt := &survey.Confirm{
+			Message: message,
+		}
+		if defaultBool, ok := def.Default.(bool); ok {
+			prompt.Default = defaultBool
+		}
+
+		var value bool
+		err := ask(prompt, &value, nil)
+		return strconv.FormatBool(value), err
+	default:
+		prompt := &survey.Input{
+			Message: message,
+			Default: defaultValue,
+		}
+
+		var value string
+		err := ask(prompt, &value, validator)
+		return value, err
+	}
+}
@@ -0,0 +1,143 @@
+package parameters
+
+import (
+	"testing"
+
+	"get.porter.sh/porter/pkg/cnab/extensions"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+)
+
+// fakeAsk answers each prompt with the next answer, after validating it.
+type fakeAsk struct {
+	answers []interface{}
+	prompts []survey.Prompt
+}
+
+func (f *fakeAsk) Ask(prompt survey.Prompt, response interface{}, validator survey.Validator) error {
+	answer := f.answers[len(f.prompts)]
+	f.prompts = append(f.prompts, prompt)
+
+	if validator != nil {
+		if err := validator(answer); err != nil {
+			return err
+		}
+	}
+
+	switch r := response.(type) {
+	case *string:
+		*r = answer.(string)
+	case *bool:
+		*r = answer.(bool)
+	}
+	return nil
+}
+
+func newPromptTestBundle() *bundle.Bundle {
+	sensitive := true
+	min := 1
+	return &bundle.Bundle{
+		Name: "mybuns",
+		Definitions: definition.Definitions{
+			"password": &definition.Schema{Type: "string", WriteOnly: &sensitive, Default: "changeme"},
+			"region":   &definition.Schema{Type: "string", Enum: []interface{}{"eastus", "westus"}, Default: "eastus"},
+			"replicas": &definition.Schema{Type: "integer", Minimum: &min, Default: 1},
+			"debug":    &definition.Schema{Type: "boolean", Default: false},
+			"name":     &definition.Schema{Type: "string", Description: "The name of the app"},
+			"tag":      &definition.Schema{Type: "string"},
+		},
+		Parameters: map[string]bundle.Parameter{
+			"password":     {Definition: "password"},
+			"region":       {Definition: "region", Description: "Azure region"},
+			"replicas":     {Definition: "replicas"},
+			"debug":        {Definition: "debug"},
+			"name":         {Definition: "name", Required: true},
+			"tag":          {Definition: "tag", ApplyTo: []string{"upgrade"}},
+			"porter-debug": {Definition: "debug"},
+		},
+	}
+}
+
+func TestPromptForParameters(t *testing.T) {
+	bun := newPromptTestBundle()
+	ask := &fakeAsk{
+		// debug, name, password, region, replicas
+		answers: []interface{}{true, "myapp", "", "westus", "1"},
+	}
+
+	values, err := PromptForParameters(PromptOptions{
+		Bundle: bun,
+		Action: "install",
+		Ask:    ask.Ask,
+	})
+	require.NoError(t, err)
+
+	wantValues := map[string]string{
+		"debug":  "true",
+		"name":   "myapp",
+		"region": "westus",
+	}
+	assert.Equal(t, wantValues, values, "values that match the default should not be returned")
+
+	require.Len(t, ask.prompts, 5, "porter parameters and parameters that don't apply to the action should not be prompted")
+	assert.IsType(t, &survey.Confirm{}, ask.prompts[0], "boolean parameters should be confirmed")
+	assert.Equal(t, `Enter a value for parameter "name" (The name of the app)`, ask.prompts[1].(*survey.Input).Message)
+	assert.IsType(t, &survey.Password{}, ask.prompts[2], "sensitive parameters should hide their input")
+	region := ask.prompts[3].(*survey.Select)
+	assert.Equal(t, `Enter a value for parameter "region" (Azure region)`, region.Message)
+	assert.Equal(t, []string{"eastus", "westus"}, region.Options)
+	assert.Equal(t, "eastus", region.Default)
+	assert.Equal(t, "1", ask.prompts[4].(*survey.Input).Default)
+}
+
+func TestPromptForParameters_Specified(t *testing.T) {
+	bun := newPromptTestBundle()
+	bun.Custom = map[string]interface{}{
+		extensions.ParameterSourcesKey: extensions.ParameterSources{
+			"replicas": extensions.ParameterSource{Installation: "mysql", Output: "replicas"},
+		},
+	}
+	ask := &fakeAsk{
+		// debug, name
+		answers: []interface{}{false, "myapp"},
+	}
+
+	values, err := PromptForParameters(PromptOptions{
+		Bundle:    bun,
+		Action:    "install",
+		Specified: map[string]string{"password": "s3cr3t", "region": "westus"},
+		Ask:       ask.Ask,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"name": "myapp"}, values)
+	assert.Len(t, ask.prompts, 2, "specified parameters and parameters with a source should not be prompted")
+}
+
+func TestPromptForParameters_Validation(t *testing.T) {
+	testcases := []struct {
+		name      string
+		answers   []interface{}
+		wantError string
+	}{
+		{name: "required", answers: []interface{}{false, ""}, wantError: "could not prompt for parameter name: a value is required"},
+		{name: "invalid", answers: []interface{}{false, "myapp", "", "eastus", "0"}, wantError: "could not prompt for parameter replicas: must be greater than or equal to 1.000000"},
+		{name: "wrong type", answers: []interface{}{false, "myapp", "", "eastus", "two"}, wantError: `could not prompt for parameter replicas: "two" is not a valid integer`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ask := &fakeAsk{answers: tc.answers}
+
+			_, err := PromptForParameters(PromptOptions{
+				Bundle: newPromptTestBundle(),
+				Action: "install",
+				Ask:    ask.Ask,
+			})
+			require.EqualError(t, err, tc.wantError)
+		})
+	}
+}
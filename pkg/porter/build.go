@@ -9,6 +9,7 @@ import (
 
 	"get.porter.sh/porter/pkg/build"
 	configadapter "get.porter.sh/porter/pkg/cnab/config-adapter"
+	"get.porter.sh/porter/pkg/cnab/extensions"
 	"github.com/cnabio/cnab-go/bundle"
 	"github.com/pkg/errors"
 )
@@ -20,6 +21,9 @@ type BuildProvider interface {
 
 type BuildOptions struct {
 	contextOptions
+
+	// AllowMissingSchemas skips validating the steps of mixins that do not provide a schema.
+	AllowMissingSchemas bool
 }
 
 func (p *Porter) Build(opts BuildOptions) error {
@@ -30,6 +34,22 @@ func (p *Porter) Build(opts BuildOptions) error {
 		return err
 	}
 
+	err = p.validateManifestSchema(p.Manifest, opts.AllowMissingSchemas)
+	if err != nil {
+		return err
+	}
+
+	// Resolve the dependencies once, so that the templates are checked against the versions that are locked
+	graph, err := p.resolveDependencyGraph(BundlePullOptions{}, false)
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve the bundle's dependencies")
+	}
+
+	err = p.validateManifestTemplates(graph)
+	if err != nil {
+		return err
+	}
+
 	generator := build.NewDockerfileGenerator(p.Config, p.Manifest, p.Templates, p.Mixins)
 
 	if err := generator.PrepareFilesystem(); err != nil {
@@ -42,7 +62,27 @@ func (p *Porter) Build(opts BuildOptions) error {
 		return errors.Wrap(err, "unable to build CNAB invocation image")
 	}
 
-	return p.buildBundle(p.Manifest.Image, "")
+	locks, err := p.writeDependencyLocks(graph)
+	if err != nil {
+		return errors.Wrap(err, "unable to lock the bundle's dependencies")
+	}
+
+	return p.buildBundle(p.Manifest.Image, "", locks)
+}
+
+// validateManifestTemplates checks the templates in the steps of the manifest against the
+// bundle and its resolved dependencies, so that a typo is found before the invocation image is built.
+func (p *Porter) validateManifestTemplates(graph *extensions.DependencyGraph) error {
+	var dependencies map[string]*bundle.Bundle
+	if graph != nil {
+		dependencies = make(map[string]*bundle.Bundle, len(graph.Root.Requires))
+		for alias, node := range graph.Root.Requires {
+			dependencies[alias] = node.Bundle
+		}
+	}
+
+	err := p.Manifest.ValidateTemplates(dependencies)
+	return errors.Wrap(err, "invalid template in the manifest")
 }
 
 func (p *Porter) getUsedMixins() ([]mixin.Metadata, error) {
@@ -64,7 +104,8 @@ func (p *Porter) getUsedMixins() ([]mixin.Metadata, error) {
 	return usedMixins, nil
 }
 
-func (p *Porter) buildBundle(invocationImage string, digest string) error {
+// buildBundle writes the bundle.json for the manifest, recording the locked versions of its dependencies.
+func (p *Porter) buildBundle(invocationImage string, digest string, locks extensions.DependencyLocks) error {
 	imageDigests := map[string]string{invocationImage: digest}
 
 	mixins, err := p.getUsedMixins()
@@ -74,6 +115,7 @@ func (p *Porter) buildBundle(invocationImage string, digest string) error {
 	}
 
 	converter := configadapter.NewManifestConverter(p.Context, p.Manifest, imageDigests, mixins)
+	converter.DependencyLocks = locks
 	bun := converter.ToBundle()
 	return p.writeBundle(bun)
 }
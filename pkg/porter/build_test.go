@@ -23,7 +23,7 @@ func TestPorter_buildBundle(t *testing.T) {
 	err = p.LoadManifest()
 	require.NoError(t, err)
 
-	err = p.buildBundle("foo", "digest")
+	err = p.buildBundle("foo", "digest", nil)
 	require.NoError(t, err)
 
 	bundleJSONExists, err := p.FileSystem.Exists(build.LOCAL_BUNDLE)
@@ -67,7 +67,7 @@ func TestPorter_paramRequired(t *testing.T) {
 	err := p.LoadManifest()
 	require.NoError(t, err)
 
-	err = p.buildBundle("foo", "digest")
+	err = p.buildBundle("foo", "digest", nil)
 	require.NoError(t, err)
 
 	bundleBytes, err := p.FileSystem.ReadFile(build.LOCAL_BUNDLE)
@@ -80,3 +80,26 @@ func TestPorter_paramRequired(t *testing.T) {
 	require.False(t, bundle.Parameters["command"].Required, "expected command param to not be required")
 	require.True(t, bundle.Parameters["command2"].Required, "expected command2 param to be required")
 }
+
+func TestPorter_validateManifestTemplates(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+	p.TestConfig.TestContext.AddTestFile("../../build/testdata/bundles/wordpress/porter.yaml", config.Name)
+	p.CNAB = NewTestCNABProvider()
+	p.Cache = &mockCache{
+		findBundleMock: func(tag string) (string, string, bool, error) {
+			return "/cache/" + tag + "/bundle.json", "", true, nil
+		},
+	}
+
+	err := p.LoadManifest()
+	require.NoError(t, err)
+
+	graph, err := p.resolveDependencyGraph(BundlePullOptions{}, false)
+	require.NoError(t, err)
+
+	// The test dependency bundle doesn't define any outputs
+	err = p.validateManifestTemplates(graph)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `the install action step "Install Wordpress" references bundle.dependencies.mysql.outputs.mysql-password, which is not defined`)
+}
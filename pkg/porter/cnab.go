@@ -1,7 +1,6 @@
 package porter
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -23,6 +22,7 @@ type CNABProvider interface {
 	Upgrade(arguments cnabprovider.ActionArguments) error
 	Invoke(action string, arguments cnabprovider.ActionArguments) error
 	Uninstall(arguments cnabprovider.ActionArguments) error
+	Status(arguments cnabprovider.ActionArguments) (map[string]interface{}, error)
 }
 
 const DefaultDriver = "docker"
@@ -65,9 +65,13 @@ type sharedOptions struct {
 	// Params is the unparsed list of NAME=VALUE parameters set on the command line.
 	Params []string
 
-	// ParamFiles is a list of file paths containing lines of NAME=VALUE parameter definitions.
+	// ParamFiles is a list of file paths containing parameter definitions, either
+	// YAML or JSON files, or lines of NAME=VALUE.
 	ParamFiles []string
 
+	// ParameterSets is a list of parameter set names to make available to the bundle.
+	ParameterSets []string
+
 	// CredentialIdentifiers is a list of credential names or paths to make available to the bundle.
 	CredentialIdentifiers []string
 
@@ -80,7 +84,11 @@ type sharedOptions struct {
 	// parsedParamFiles is the parsed set of parameters from Params.
 	parsedParamFiles []map[string]string
 
-	// combinedParameters is parsedParams merged on top of parsedParamFiles.
+	// parsedParamSets is the resolved set of parameters from ParameterSets.
+	parsedParamSets []map[string]string
+
+	// combinedParameters is parsedParams merged on top of parsedParamFiles,
+	// which are merged on top of parsedParamSets.
 	combinedParameters map[string]string
 }
 
@@ -254,22 +262,12 @@ func (o *sharedOptions) parseParamFiles(cxt *context.Context) error {
 }
 
 func (o *sharedOptions) parseParamFile(path string, cxt *context.Context) error {
-	f, err := cxt.FileSystem.Open(path)
+	data, err := cxt.FileSystem.ReadFile(path)
 	if err != nil {
 		return errors.Wrapf(err, "could not read param file %s", path)
 	}
-	defer f.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	if err := scanner.Err(); err != nil {
-		return errors.Wrapf(err, "unable to read contents of param file %s", path)
-	}
 
-	p, err := parameters.ParseVariableAssignments(lines)
+	p, err := parameters.ParseParameterFile(path, data)
 	if err != nil {
 		return err
 	}
@@ -279,11 +277,18 @@ func (o *sharedOptions) parseParamFile(path string, cxt *context.Context) error
 }
 
 // Combine the parameters into a single map
-// The params set on the command line take precedence over the params set in files
+// The params set on the command line take precedence over the params set in files,
+// which take precedence over the params set in parameter sets.
 // Anything set multiple times, is decided by "last one set wins"
 func (o *sharedOptions) combineParameters() map[string]string {
 	final := make(map[string]string)
 
+	for _, ps := range o.parsedParamSets {
+		for k, v := range ps {
+			final[k] = v
+		}
+	}
+
 	for _, pf := range o.parsedParamFiles {
 		for k, v := range pf {
 			final[k] = v
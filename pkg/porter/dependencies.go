@@ -1,17 +1,22 @@
 package porter
 
 import (
+	"bytes"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"get.porter.sh/porter/pkg/claims"
 	"get.porter.sh/porter/pkg/cnab/extensions"
 	cnabprovider "get.porter.sh/porter/pkg/cnab/provider"
 	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/credentials"
 	"get.porter.sh/porter/pkg/manifest"
 	"get.porter.sh/porter/pkg/runtime"
+	"github.com/cbroglie/mustache"
 	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/claim"
 	"github.com/pkg/errors"
 )
 
@@ -19,14 +24,24 @@ type dependencyExecutioner struct {
 	*context.Context
 	// See https://github.com/deislabs/porter/issues/799
 	// Manifest        *manifest.Manifest
-	Resolver BundleResolver
-	CNAB     CNABProvider
-	Claims   claims.ClaimProvider
+	Resolver    BundleResolver
+	CNAB        CNABProvider
+	Claims      claims.ClaimProvider
+	Credentials credentials.CredentialProvider
 
 	// These are populated by Prepare, call it or perish in inevitable errors
 	parentOpts BundleLifecycleOpts
 	action     cnabAction
-	deps       []*queuedDependency
+
+	// bundle is the definition of the parent bundle.
+	bundle *bundle.Bundle
+
+	// deps is every dependency of the parent bundle, including dependencies of
+	// dependencies, in the order they should be installed.
+	deps []*queuedDependency
+
+	// requires maps the aliases used by the parent bundle to its direct dependencies.
+	requires map[string]*queuedDependency
 }
 
 func newDependencyExecutioner(p *Porter) *dependencyExecutioner {
@@ -38,9 +53,10 @@ func newDependencyExecutioner(p *Porter) *dependencyExecutioner {
 		Context: p.Context,
 		// See https://github.com/deislabs/porter/issues/799
 		// Manifest:        p.Manifest,
-		Resolver: resolver,
-		CNAB:     p.CNAB,
-		Claims:   p.Claims,
+		Resolver:    resolver,
+		CNAB:        p.CNAB,
+		Claims:      p.Claims,
+		Credentials: p.Credentials,
 	}
 }
 
@@ -51,8 +67,24 @@ type queuedDependency struct {
 	CNABFile   string
 	Parameters map[string]string
 
+	// Installation is the name of the installation (claim) for the dependency.
+	Installation string
+
+	// shared indicates that the dependency is bound to an existing installation,
+	// which is referenced by the parent instead of being managed by it.
+	shared bool
+
+	// bundle is the definition of the dependency, loaded in prepareDependency.
+	bundle *bundle.Bundle
+
+	// requires maps the aliases used by this dependency to the dependencies that it requires.
+	requires map[string]*queuedDependency
+
 	outputs map[string]interface{}
 
+	// parameters are the resolved parameter values that were passed to the dependency
+	parameters map[string]interface{}
+
 	// cache of the CNAB file contents
 	cnabFileContents []byte
 
@@ -68,6 +100,11 @@ func (e *dependencyExecutioner) Prepare(parentOpts BundleLifecycleOpts, action c
 		return err
 	}
 
+	err = e.bindSharedDependencies()
+	if err != nil {
+		return err
+	}
+
 	for _, dep := range e.deps {
 		err := e.prepareDependency(dep)
 		if err != nil {
@@ -75,7 +112,12 @@ func (e *dependencyExecutioner) Prepare(parentOpts BundleLifecycleOpts, action c
 		}
 	}
 
-	return nil
+	err = e.validateDependencyCredentials()
+	if err != nil {
+		return err
+	}
+
+	return e.applyDependencyParameters()
 }
 
 func (e *dependencyExecutioner) Execute(action manifest.Action) error {
@@ -85,7 +127,7 @@ func (e *dependencyExecutioner) Execute(action manifest.Action) error {
 
 	// executeDependency the requested action against all of the dependencies
 	parentArgs := e.parentOpts.ToActionArgs(e)
-	for _, dep := range e.deps {
+	for _, dep := range e.orderDependencies(action) {
 		err := e.executeDependency(dep, parentArgs, action)
 		if err != nil {
 			return err
@@ -95,21 +137,59 @@ func (e *dependencyExecutioner) Execute(action manifest.Action) error {
 	return nil
 }
 
+// orderDependencies returns the dependencies in the order that the action is executed.
+// Dependencies are uninstalled in the reverse order that they were installed,
+// so that a bundle is always removed before the bundles that it depends upon.
+func (e *dependencyExecutioner) orderDependencies(action manifest.Action) []*queuedDependency {
+	if action != manifest.ActionUninstall {
+		return e.deps
+	}
+
+	reversed := make([]*queuedDependency, len(e.deps))
+	for i, dep := range e.deps {
+		reversed[len(e.deps)-1-i] = dep
+	}
+	return reversed
+}
+
 func (e *dependencyExecutioner) ApplyDependencyMappings(args *cnabprovider.ActionArguments) {
+	applyDependencyMappings(args, e.requires)
+
+	// Remember the shared installations on the parent, so that they are used by its next action
+	for _, dep := range e.deps {
+		if !dep.shared {
+			continue
+		}
+		if args.SharedDependencies == nil {
+			args.SharedDependencies = make(map[string]string)
+		}
+		args.SharedDependencies[dep.Alias] = dep.Installation
+	}
+}
+
+// applyDependencyMappings defines the files for a bundle's direct dependencies
+// that need to be copied into the bundle, using the aliases known to that bundle.
+func applyDependencyMappings(args *cnabprovider.ActionArguments, requires map[string]*queuedDependency) {
 	if args.Files == nil {
-		args.Files = make(map[string]string, 2*len(e.deps))
+		args.Files = make(map[string]string, 2*len(requires))
 	}
 
 	// Define files necessary for dependencies that need to be copied into the bundle
 	// args.Files is a map of target path to file contents
-	for _, dep := range e.deps {
+	for alias, dep := range requires {
 		// Copy the dependency bundle.json
-		target := runtime.GetDependencyDefinitionPath(dep.Alias)
+		target := runtime.GetDependencyDefinitionPath(alias)
 		args.Files[target] = string(dep.cnabFileContents)
 
 		// Copy the dependency output files defined from the bundle.json (loaded in prepareDependency)
 		for output, value := range dep.outputs {
-			target := filepath.Join(runtime.GetDependencyOutputsDir(dep.Alias), filepath.Base(output))
+			target := filepath.Join(runtime.GetDependencyOutputsDir(alias), filepath.Base(output))
+			args.Files[target] = fmt.Sprintf("%v", value)
+		}
+
+		// Copy the parameter values that were passed to the dependency, collected from its claim
+		for param, value := range dep.parameters {
+			target := filepath.Join(runtime.GetDependencyParametersDir(alias), filepath.Base(param))
 			args.Files[target] = fmt.Sprintf("%v", value)
 		}
 	}
@@ -131,33 +211,156 @@ func (e *dependencyExecutioner) identifyDependencies() error {
 	} else {
 		bun, _ = e.CNAB.LoadBundle(e.parentOpts.CNABFile, e.parentOpts.Insecure)
 	}
+	e.bundle = bun
+
+	// Use the dependency versions that were locked when the bundle was built
+	locks, err := extensions.ReadDependencyLocks(bun)
+	if err != nil {
+		return err
+	}
 
-	solver := &extensions.DependencySolver{}
-	locks, err := solver.ResolveDependencies(bun)
+	solver := &extensions.DependencySolver{Locks: locks}
+	graph, err := solver.ResolveDependencyGraph(bun, e.loadDependencyBundle)
 	if err != nil {
 		return err
 	}
 
-	e.deps = make([]*queuedDependency, len(locks))
-	for i, lock := range locks {
+	queued := make(map[*extensions.DependencyNode]*queuedDependency, len(graph.InstallOrder))
+	e.deps = make([]*queuedDependency, len(graph.InstallOrder))
+	for i, node := range graph.InstallOrder {
 		if e.Debug {
-			fmt.Fprintf(e.Out, "Resolved dependency %s to %s\n", lock.Alias, lock.Tag)
+			fmt.Fprintf(e.Out, "Resolved dependency %s to %s\n", node.Alias, node.Tag)
 		}
 		e.deps[i] = &queuedDependency{
-			DependencyLock: lock,
+			DependencyLock: node.DependencyLock,
+		}
+		queued[node] = e.deps[i]
+	}
+
+	// Link each dependency to the dependencies that it requires, now that they are all queued
+	for node, dep := range queued {
+		dep.requires = linkDependencies(node, queued)
+	}
+	e.requires = linkDependencies(graph.Root, queued)
+
+	return nil
+}
+
+// bindSharedDependencies determines which dependencies use an existing installation, either
+// from --dependency-installation or from a reference recorded by a previous action, and names
+// the installation of every other dependency after the parent installation.
+func (e *dependencyExecutioner) bindSharedDependencies() error {
+	bindings, err := e.findSharedDependencies()
+	if err != nil {
+		return err
+	}
+	for alias, installation := range e.parentOpts.sharedDependencies {
+		bindings[alias] = installation
+	}
+
+	bound := make(map[string]bool, len(bindings))
+	for _, dep := range e.deps {
+		if installation, ok := bindings[dep.Alias]; ok {
+			dep.Installation = installation
+			dep.shared = true
+			bound[dep.Alias] = true
+		} else {
+			dep.Installation = fmt.Sprintf("%s-%s", e.parentOpts.Name, dep.Alias)
 		}
 	}
 
+	for alias := range e.parentOpts.sharedDependencies {
+		if !bound[alias] {
+			return errors.Errorf("invalid --dependency-installation %s, %s is not a dependency of the bundle", alias, alias)
+		}
+	}
+
+	e.pruneSharedDependencies()
 	return nil
 }
 
+// findSharedDependencies returns the dependencies of the parent installation that were
+// bound to a shared installation by a previous action, keyed by the dependency alias.
+func (e *dependencyExecutioner) findSharedDependencies() (map[string]string, error) {
+	c, err := e.Claims.Read(e.parentOpts.Name)
+	if err != nil {
+		if err == claim.ErrClaimNotFound {
+			return make(map[string]string), nil
+		}
+		return nil, errors.Wrapf(err, "could not read the installation %s", e.parentOpts.Name)
+	}
+
+	bindings, err := claims.ReadSharedDependencies(c)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read the shared dependencies of the installation %s", e.parentOpts.Name)
+	}
+	if bindings == nil {
+		bindings = make(map[string]string)
+	}
+	return bindings, nil
+}
+
+// pruneSharedDependencies removes dependencies that are only required by shared
+// installations, since they are managed along with the shared installation.
+func (e *dependencyExecutioner) pruneSharedDependencies() {
+	needed := make(map[*queuedDependency]bool, len(e.deps))
+	var visit func(requires map[string]*queuedDependency)
+	visit = func(requires map[string]*queuedDependency) {
+		for _, dep := range requires {
+			if needed[dep] {
+				continue
+			}
+			needed[dep] = true
+			if !dep.shared {
+				visit(dep.requires)
+			}
+		}
+	}
+	visit(e.requires)
+
+	deps := make([]*queuedDependency, 0, len(e.deps))
+	for _, dep := range e.deps {
+		if needed[dep] {
+			deps = append(deps, dep)
+		}
+	}
+	e.deps = deps
+}
+
+func linkDependencies(node *extensions.DependencyNode, queued map[*extensions.DependencyNode]*queuedDependency) map[string]*queuedDependency {
+	requires := make(map[string]*queuedDependency, len(node.Requires))
+	for alias, depNode := range node.Requires {
+		requires[alias] = queued[depNode]
+	}
+	return requires
+}
+
+// loadDependencyBundle pulls a dependency so that its own dependencies can be resolved.
+func (e *dependencyExecutioner) loadDependencyBundle(tag string) (*bundle.Bundle, error) {
+	pullOpts := BundlePullOptions{
+		Tag:              tag,
+		InsecureRegistry: e.parentOpts.InsecureRegistry,
+		Force:            e.parentOpts.Force,
+	}
+	return pullDependencyBundle(e.Resolver, e.CNAB, pullOpts, e.parentOpts.Insecure)
+}
+
+// pullDependencyBundle pulls a dependency into the bundle cache and loads its bundle definition.
+func pullDependencyBundle(resolver BundleResolver, cnab CNABProvider, pullOpts BundlePullOptions, insecure bool) (*bundle.Bundle, error) {
+	cnabFile, _, err := resolver.Resolve(pullOpts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error pulling dependency %s", pullOpts.Tag)
+	}
+
+	return cnab.LoadBundle(cnabFile, insecure)
+}
+
 func (e *dependencyExecutioner) prepareDependency(dep *queuedDependency) error {
-	// Pull the dependency
+	// Locate the dependency, it was already pulled when the dependency graph was resolved
 	var err error
 	pullOpts := BundlePullOptions{
 		Tag:              dep.Tag,
 		InsecureRegistry: e.parentOpts.InsecureRegistry,
-		Force:            e.parentOpts.Force,
 	}
 	dep.CNABFile, dep.RelocationMapping, err = e.Resolver.Resolve(pullOpts)
 	if err != nil {
@@ -174,6 +377,7 @@ func (e *dependencyExecutioner) prepareDependency(dep *queuedDependency) error {
 	if err != nil {
 		return errors.Wrapf(err, "invalid bundle %s", dep.Alias)
 	}
+	dep.bundle = depBun
 
 	// Cache the bundle.json for later
 	dep.cnabFileContents, err = e.FileSystem.ReadFile(dep.CNABFile)
@@ -187,26 +391,6 @@ func (e *dependencyExecutioner) prepareDependency(dep *queuedDependency) error {
 		depParams[paramName] = struct{}{}
 	}
 
-	// Handle any parameter overrides for the dependency defined in porter.yaml
-	// dependencies:
-	//   DEP:
-	//     parameters:
-	//       PARAM: VALUE
-	/* Disabling this until we have access to the manifest when working with a bundle from a tag
-	   See https://github.com/deislabs/porter/issues/799
-	for paramName, value := range e.Manifest.Dependencies[dep.Alias].Parameters {
-		// Make sure the parameter is defined in the bundle
-		if _, ok := depParams[paramName]; !ok {
-			return errors.Errorf("invalid dependencies.%s.parameters entry, %s is not a parameter defined in that bundle", dep.Alias, paramName)
-		}
-
-		if dep.Parameters == nil {
-			dep.Parameters = make(map[string]string, 1)
-		}
-		dep.Parameters[paramName] = value
-	}
-	*/
-
 	// Handle any parameter overrides for the dependency defined on the command line
 	// --param DEP#PARAM=VALUE
 	for key, value := range e.parentOpts.combinedParameters {
@@ -219,6 +403,11 @@ func (e *dependencyExecutioner) prepareDependency(dep *queuedDependency) error {
 				return errors.Errorf("invalid --param %s, %s is not a parameter defined in the bundle %s", key, paramName, dep.Alias)
 			}
 
+			// A shared installation is not modified by the parent
+			if dep.shared {
+				return errors.Errorf("invalid --param %s, dependency %s is bound to the shared installation %s", key, dep.Alias, dep.Installation)
+			}
+
 			if dep.Parameters == nil {
 				dep.Parameters = make(map[string]string, 1)
 			}
@@ -231,33 +420,336 @@ func (e *dependencyExecutioner) prepareDependency(dep *queuedDependency) error {
 }
 
 func (e *dependencyExecutioner) executeDependency(dep *queuedDependency, parentArgs cnabprovider.ActionArguments, action manifest.Action) error {
+	if dep.shared {
+		return e.useSharedDependency(dep, action)
+	}
+
 	depArgs := cnabprovider.ActionArguments{
 		Insecure:          parentArgs.Insecure,
 		BundlePath:        dep.CNABFile,
-		Claim:             fmt.Sprintf("%s-%s", parentArgs.Claim, dep.Alias),
+		Claim:             dep.Installation,
 		Driver:            parentArgs.Driver,
 		Params:            dep.Parameters,
 		RelocationMapping: dep.RelocationMapping,
 
-		// For now, assume it's okay to give the dependency the same credentials as the parent
-		CredentialIdentifiers: parentArgs.CredentialIdentifiers,
+		CredentialIdentifiers: e.dependencyCredentials(dep.Alias),
 	}
-	fmt.Fprintf(e.Out, "Executing dependency %s...\n", dep.Alias)
-	err := e.action(depArgs)
-	if err != nil {
-		return errors.Wrapf(err, "error executing dependency %s", dep.Alias)
+	applyDependencyMappings(&depArgs, dep.requires)
+
+	var err error
+	depAction := e.action
+	if e.parentOpts.SkipDependencies {
+		fmt.Fprintf(e.Out, "Skipping dependency %s...\n", dep.Alias)
+		depAction = nil
+	} else if action == manifest.ActionUpgrade {
+		depAction, err = e.determineUpgradeAction(dep, depArgs.Claim)
+		if err != nil {
+			return err
+		}
+	}
+
+	if depAction != nil && e.parentOpts.Resume {
+		state, err := getResumeState(e.Claims, depArgs.Claim, action)
+		if err != nil {
+			return err
+		}
+
+		switch state {
+		case resumeCompleted:
+			fmt.Fprintf(e.Out, "Skipping dependency %s, its last %s completed...\n", dep.Alias, action)
+			depAction = nil
+		case resumeFailed:
+			depArgs.Resume = true
+		}
+	}
+
+	if depAction != nil {
+		fmt.Fprintf(e.Out, "Executing dependency %s...\n", dep.Alias)
+		err = depAction(depArgs)
+		if err != nil {
+			return errors.Wrapf(err, "error executing dependency %s", dep.Alias)
+		}
 	}
 
 	// If action is uninstall, no claim will exist
 	if action != manifest.ActionUninstall {
-		// Collect expected outputs via claim
+		// Collect expected outputs and resolved parameters via claim
 		c, err := e.Claims.Read(depArgs.Claim)
 		if err != nil {
+			// A skipped dependency may not have been installed, so there aren't any outputs to collect
+			if e.parentOpts.SkipDependencies && err == claim.ErrClaimNotFound {
+				return nil
+			}
 			return err
 		}
 
 		dep.outputs = c.Outputs
+		dep.parameters = c.Parameters
+	}
+
+	return nil
+}
+
+// dependencyCredentials returns the credential sets used by a dependency: the credential
+// sets of the parent, followed by any forwarded to the dependency with --cred ALIAS#NAME.
+func (e *dependencyExecutioner) dependencyCredentials(alias string) []string {
+	forwarded := e.parentOpts.dependencyCredentials[alias]
+	creds := make([]string, 0, len(e.parentOpts.CredentialIdentifiers)+len(forwarded))
+	creds = append(creds, e.parentOpts.CredentialIdentifiers...)
+	return append(creds, forwarded...)
+}
+
+// validateDependencyCredentials checks that credential sets are only forwarded to
+// dependencies that are managed by the parent.
+func (e *dependencyExecutioner) validateDependencyCredentials() error {
+	for alias, creds := range e.parentOpts.dependencyCredentials {
+		var dep *queuedDependency
+		for _, d := range e.deps {
+			if d.Alias == alias {
+				dep = d
+				break
+			}
+		}
+
+		if dep == nil {
+			return errors.Errorf("invalid --cred %s#%s, %s is not a dependency of the bundle", alias, creds[0], alias)
+		}
+		if dep.shared {
+			return errors.Errorf("invalid --cred %s#%s, dependency %s is bound to the shared installation %s", alias, creds[0], alias, dep.Installation)
+		}
+	}
+	return nil
+}
+
+// applyDependencyParameters evaluates the parameter values that each bundle passes to its
+// dependencies, defined in the dependencies section of its manifest. The values may be
+// templates that reference the resolved parameters and credentials of that bundle.
+// Parameters set with --param ALIAS#NAME take precedence over these values.
+func (e *dependencyExecutioner) applyDependencyParameters() error {
+	err := e.applyParametersFrom(e.bundle, e.parentOpts.combinedParameters, e.parentOpts.CredentialIdentifiers, e.requires)
+	if err != nil {
+		return err
+	}
+
+	// Evaluate each dependency before the dependencies that it requires,
+	// so that its own parameters are resolved when they are referenced.
+	for i := len(e.deps) - 1; i >= 0; i-- {
+		dep := e.deps[i]
+		if dep.shared {
+			continue
+		}
+
+		err := e.applyParametersFrom(dep.bundle, dep.Parameters, e.dependencyCredentials(dep.Alias), dep.requires)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyParametersFrom sets the parameters that a bundle passes to its dependencies.
+func (e *dependencyExecutioner) applyParametersFrom(bun *bundle.Bundle, params map[string]string, creds []string, requires map[string]*queuedDependency) error {
+	if bun == nil {
+		return nil
+	}
+
+	depParams, err := extensions.ReadDependencyParameters(bun)
+	if err != nil {
+		return err
+	}
+
+	var data map[string]interface{}
+	for alias, values := range depParams {
+		dep, ok := requires[alias]
+		if !ok || dep.shared {
+			continue
+		}
+
+		for name, value := range values {
+			// Make sure the parameter is defined in the bundle
+			if dep.bundle != nil {
+				if _, ok := dep.bundle.Parameters[name]; !ok {
+					return errors.Errorf("invalid dependencies.%s.parameters entry, %s is not a parameter defined in that bundle", alias, name)
+				}
+			}
+
+			if _, set := dep.Parameters[name]; set {
+				continue
+			}
+
+			if data == nil {
+				data, err = e.buildDependencyTemplateData(bun, params, creds, depParams)
+				if err != nil {
+					return err
+				}
+			}
+
+			mustache.AllowMissingVariables = false
+			rendered, err := mustache.RenderRaw(value, true, data)
+			if err != nil {
+				return errors.Wrapf(err, "could not evaluate the dependencies.%s.parameters.%s entry in bundle %s", alias, name, bun.Name)
+			}
+
+			if dep.Parameters == nil {
+				dep.Parameters = make(map[string]string, len(values))
+			}
+			dep.Parameters[name] = rendered
+		}
+	}
+
+	return nil
+}
+
+// buildDependencyTemplateData builds the data available to the templates in the parameters
+// that a bundle passes to its dependencies: the bundle's resolved parameters and credentials.
+func (e *dependencyExecutioner) buildDependencyTemplateData(bun *bundle.Bundle, params map[string]string, creds []string, depParams extensions.DependencyParameters) (map[string]interface{}, error) {
+	resolvedParams := make(map[string]interface{}, len(bun.Parameters))
+	for name, param := range bun.Parameters {
+		if value, ok := params[name]; ok {
+			resolvedParams[name] = value
+		} else if def, ok := bun.Definitions[param.Definition]; ok && def.Default != nil {
+			resolvedParams[name] = def.Default
+		}
+	}
+
+	// Only resolve credentials when they are referenced, because it may require accessing a secret store
+	resolvedCreds := map[string]string{}
+	for _, values := range depParams {
+		for _, value := range values {
+			if strings.Contains(value, "bundle.credentials") {
+				var err error
+				resolvedCreds, err = e.resolveCredentials(creds)
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+	}
+
+	data := map[string]interface{}{
+		"bundle": map[string]interface{}{
+			"name":        bun.Name,
+			"version":     bun.Version,
+			"parameters":  resolvedParams,
+			"credentials": resolvedCreds,
+		},
+	}
+	return data, nil
+}
+
+// resolveCredentials resolves the values of the credential sets, where the last set wins.
+func (e *dependencyExecutioner) resolveCredentials(creds []string) (map[string]string, error) {
+	resolved := make(map[string]string)
+	for _, name := range creds {
+		cset, err := e.Credentials.Read(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read credential set %s", name)
+		}
+
+		values, err := e.Credentials.ResolveAll(cset)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not resolve credential set %s", name)
+		}
+
+		for k, v := range values {
+			resolved[k] = v
+		}
+	}
+	return resolved, nil
+}
+
+// useSharedDependency references the existing installation bound to a dependency,
+// instead of executing the action against it. Only the reference from the parent
+// installation is recorded, or removed when the parent is uninstalled.
+func (e *dependencyExecutioner) useSharedDependency(dep *queuedDependency, action manifest.Action) error {
+	c, err := e.Claims.Read(dep.Installation)
+	if err != nil {
+		if err == claim.ErrClaimNotFound {
+			if action == manifest.ActionUninstall {
+				return nil
+			}
+			return errors.Errorf("dependency %s is bound to the installation %s, which does not exist", dep.Alias, dep.Installation)
+		}
+		return errors.Wrapf(err, "could not read the installation %s for dependency %s", dep.Installation, dep.Alias)
+	}
+
+	dependent := claims.Dependent{Installation: e.parentOpts.Name, Alias: dep.Alias}
+	var changed bool
+	if action == manifest.ActionUninstall {
+		fmt.Fprintf(e.Out, "Leaving the shared installation %s for dependency %s installed\n", dep.Installation, dep.Alias)
+		changed, err = claims.RemoveDependent(&c, dependent)
+	} else {
+		fmt.Fprintf(e.Out, "Using the shared installation %s for dependency %s\n", dep.Installation, dep.Alias)
+		changed, err = claims.AddDependent(&c, dependent)
+	}
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		err = e.Claims.Save(c)
+		if err != nil {
+			return errors.Wrapf(err, "could not update the references to the shared installation %s", dep.Installation)
+		}
+	}
+
+	if action == manifest.ActionUninstall {
+		return nil
+	}
+
+	// Give the parent the outputs and bundle definition of what is actually installed
+	dep.outputs = c.Outputs
+	dep.parameters = c.Parameters
+	if c.Bundle != nil {
+		var buf bytes.Buffer
+		_, err = c.Bundle.WriteTo(&buf)
+		if err != nil {
+			return errors.Wrapf(err, "could not write the bundle definition of the shared installation %s", dep.Installation)
+		}
+		dep.cnabFileContents = buf.Bytes()
 	}
 
 	return nil
 }
+
+// determineUpgradeAction decides how to upgrade a dependency. A dependency that
+// is not installed yet is installed, and a dependency that was successfully
+// installed with its locked version and the same parameters is left as-is.
+func (e *dependencyExecutioner) determineUpgradeAction(dep *queuedDependency, claimName string) (cnabAction, error) {
+	c, err := e.Claims.Read(claimName)
+	if err != nil {
+		if err == claim.ErrClaimNotFound {
+			fmt.Fprintf(e.Out, "Dependency %s is not installed, it will be installed\n", dep.Alias)
+			return e.CNAB.Install, nil
+		}
+		return nil, errors.Wrapf(err, "could not read the installation of dependency %s", dep.Alias)
+	}
+
+	if c.Bundle == nil || dep.Digest == "" || c.Result.Status != claim.StatusSuccess {
+		return e.action, nil
+	}
+
+	digest, err := extensions.DigestBundle(c.Bundle)
+	if err != nil || digest != dep.Digest {
+		return e.action, nil
+	}
+
+	// Parameters passed to the dependency by the parent or with --param are applied by an upgrade
+	names := make([]string, 0, len(dep.Parameters))
+	for name := range dep.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		current, ok := c.Parameters[name]
+		if !ok || fmt.Sprintf("%v", current) != dep.Parameters[name] {
+			fmt.Fprintf(e.Out, "Dependency %s is up-to-date with %s but parameter %s changed, it will be upgraded\n", dep.Alias, dep.Tag, name)
+			return e.action, nil
+		}
+	}
+
+	fmt.Fprintf(e.Out, "Dependency %s is already up-to-date with %s, skipping upgrade\n", dep.Alias, dep.Tag)
+	return nil, nil
+}
@@ -3,8 +3,16 @@ package porter
 import (
 	"testing"
 
+	"get.porter.sh/porter/pkg/claims"
+	"get.porter.sh/porter/pkg/cnab/extensions"
 	cnabprovider "get.porter.sh/porter/pkg/cnab/provider"
 	"get.porter.sh/porter/pkg/manifest"
+	"get.porter.sh/porter/pkg/secrets"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/cnabio/cnab-go/credentials"
+	"github.com/cnabio/cnab-go/secrets/host"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -34,3 +42,406 @@ func TestDependencyExecutioner_ExecuteBeforePrepare(t *testing.T) {
 	err = e.Execute(manifest.ActionInstall)
 	require.NoError(t, err, "execute should not fail when we have called prepare")
 }
+
+func TestDependencyExecutioner_ApplyDependencyMappings(t *testing.T) {
+	p := NewTestPorter(t)
+	e := newDependencyExecutioner(p.Porter)
+
+	mysql := &queuedDependency{
+		cnabFileContents: []byte("mysql bundle"),
+		outputs:          map[string]interface{}{"connstr": "mysql://localhost"},
+		parameters:       map[string]interface{}{"database": "wordpress", "port": 3306},
+	}
+	mysql.Alias = "app-db"
+	app := &queuedDependency{
+		cnabFileContents: []byte("app bundle"),
+		requires:         map[string]*queuedDependency{"db": mysql},
+	}
+	app.Alias = "app"
+	e.deps = []*queuedDependency{mysql, app}
+	e.requires = map[string]*queuedDependency{"app": app}
+
+	args := cnabprovider.ActionArguments{}
+	e.ApplyDependencyMappings(&args)
+	assert.Equal(t, map[string]string{
+		"/cnab/app/dependencies/app/bundle.json": "app bundle",
+	}, args.Files, "the parent bundle should only receive its direct dependencies")
+
+	depArgs := cnabprovider.ActionArguments{}
+	applyDependencyMappings(&depArgs, app.requires)
+	assert.Equal(t, map[string]string{
+		"/cnab/app/dependencies/db/bundle.json":         "mysql bundle",
+		"/cnab/app/dependencies/db/outputs/connstr":     "mysql://localhost",
+		"/cnab/app/dependencies/db/parameters/database": "wordpress",
+		"/cnab/app/dependencies/db/parameters/port":     "3306",
+	}, depArgs.Files, "a dependency should receive its own dependencies using its aliases")
+}
+
+func newTestDependencyExecutioner(p *TestPorter, opts BundleLifecycleOpts, executed *[]string) *dependencyExecutioner {
+	e := newDependencyExecutioner(p.Porter)
+	e.parentOpts = opts
+	e.action = func(args cnabprovider.ActionArguments) error {
+		*executed = append(*executed, args.Claim)
+		return p.Claims.Save(claim.Claim{Name: args.Claim, Result: claim.Result{Status: claim.StatusSuccess}})
+	}
+
+	storage := &queuedDependency{}
+	storage.Alias = "mysql-storage"
+	mysql := &queuedDependency{requires: map[string]*queuedDependency{"storage": storage}}
+	mysql.Alias = "mysql"
+	e.deps = []*queuedDependency{storage, mysql}
+	e.requires = map[string]*queuedDependency{"mysql": mysql}
+
+	err := e.bindSharedDependencies()
+	require.NoError(p.TestConfig.TestContext.T, err)
+	return e
+}
+
+func TestDependencyExecutioner_Execute_Order(t *testing.T) {
+	testcases := []struct {
+		action    manifest.Action
+		wantOrder []string
+	}{
+		{manifest.ActionInstall, []string{"wordpress-mysql-storage", "wordpress-mysql"}},
+		{manifest.ActionUninstall, []string{"wordpress-mysql", "wordpress-mysql-storage"}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(string(tc.action), func(t *testing.T) {
+			p := NewTestPorter(t)
+			opts := BundleLifecycleOpts{}
+			opts.Name = "wordpress"
+
+			var executed []string
+			e := newTestDependencyExecutioner(p, opts, &executed)
+			err := e.Execute(tc.action)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOrder, executed)
+		})
+	}
+}
+
+func TestDependencyExecutioner_Execute_SkipDependencies(t *testing.T) {
+	p := NewTestPorter(t)
+	opts := BundleLifecycleOpts{SkipDependencies: true}
+	opts.Name = "wordpress"
+
+	err := p.Claims.Save(claim.Claim{Name: "wordpress-mysql", Outputs: map[string]interface{}{"connstr": "mysql://localhost"}})
+	require.NoError(t, err)
+
+	var executed []string
+	e := newTestDependencyExecutioner(p, opts, &executed)
+	err = e.Execute(manifest.ActionInstall)
+	require.NoError(t, err)
+	assert.Empty(t, executed, "no dependencies should have been executed")
+	assert.Equal(t, map[string]interface{}{"connstr": "mysql://localhost"}, e.requires["mysql"].outputs,
+		"the outputs of existing dependency installations should still be available")
+}
+
+func TestDependencyExecutioner_Execute_Upgrade(t *testing.T) {
+	p := NewTestPorter(t)
+	opts := BundleLifecycleOpts{}
+	opts.Name = "wordpress"
+
+	// mysql-storage is installed with the locked version, mysql is installed with an older version
+	storageBun := &bundle.Bundle{Name: "storage", Version: "1.0.0"}
+	storageDigest, err := extensions.DigestBundle(storageBun)
+	require.NoError(t, err)
+	err = p.Claims.Save(claim.Claim{Name: "wordpress-mysql-storage", Bundle: storageBun, Result: claim.Result{Status: claim.StatusSuccess}})
+	require.NoError(t, err)
+	err = p.Claims.Save(claim.Claim{Name: "wordpress-mysql", Bundle: &bundle.Bundle{Name: "mysql", Version: "0.1.0"}, Result: claim.Result{Status: claim.StatusSuccess}})
+	require.NoError(t, err)
+
+	var executed []string
+	e := newTestDependencyExecutioner(p, opts, &executed)
+	e.deps[0].Digest = storageDigest
+	e.deps[1].Digest = "sha256:newversion"
+	err = e.Execute(manifest.ActionUpgrade)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wordpress-mysql"}, executed, "only dependencies whose version changed should be upgraded")
+	assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "Dependency mysql-storage is already up-to-date")
+}
+
+func TestDependencyExecutioner_Execute_UpgradeParameters(t *testing.T) {
+	p := NewTestPorter(t)
+	opts := BundleLifecycleOpts{}
+	opts.Name = "wordpress"
+
+	// Both dependencies are installed with the locked version
+	storageBun := &bundle.Bundle{Name: "storage", Version: "1.0.0"}
+	storageDigest, err := extensions.DigestBundle(storageBun)
+	require.NoError(t, err)
+	err = p.Claims.Save(claim.Claim{Name: "wordpress-mysql-storage", Bundle: storageBun, Result: claim.Result{Status: claim.StatusSuccess},
+		Parameters: map[string]interface{}{"size": 10}})
+	require.NoError(t, err)
+	mysqlBun := &bundle.Bundle{Name: "mysql", Version: "0.1.0"}
+	mysqlDigest, err := extensions.DigestBundle(mysqlBun)
+	require.NoError(t, err)
+	err = p.Claims.Save(claim.Claim{Name: "wordpress-mysql", Bundle: mysqlBun, Result: claim.Result{Status: claim.StatusSuccess},
+		Parameters: map[string]interface{}{"database-name": "wordpress"}})
+	require.NoError(t, err)
+
+	var executed []string
+	e := newTestDependencyExecutioner(p, opts, &executed)
+	e.deps[0].Digest = storageDigest
+	e.deps[0].Parameters = map[string]string{"size": "10"}
+	e.deps[1].Digest = mysqlDigest
+	e.deps[1].Parameters = map[string]string{"database-name": "x"}
+	err = e.Execute(manifest.ActionUpgrade)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wordpress-mysql"}, executed, "dependencies whose parameters changed should be upgraded")
+
+	gotOutput := p.TestConfig.TestContext.GetOutput()
+	assert.Contains(t, gotOutput, "Dependency mysql-storage is already up-to-date")
+	assert.Contains(t, gotOutput, "parameter database-name changed, it will be upgraded")
+}
+
+func TestDependencyExecutioner_Execute_Resume(t *testing.T) {
+	p := NewTestPorter(t)
+	opts := BundleLifecycleOpts{Resume: true}
+	opts.Name = "wordpress"
+
+	// mysql-storage installed successfully, and installing mysql failed
+	err := p.Claims.Save(claim.Claim{Name: "wordpress-mysql-storage", Result: claim.Result{Action: "install", Status: claim.StatusSuccess}})
+	require.NoError(t, err)
+	err = p.Claims.Save(claim.Claim{
+		Name:   "wordpress-mysql",
+		Result: claim.Result{Action: "install", Status: claim.StatusFailure},
+		Custom: map[string]interface{}{claims.StepsKey: claims.ActionProgress{Action: "install"}},
+	})
+	require.NoError(t, err)
+
+	var executed []string
+	resumed := map[string]bool{}
+	e := newTestDependencyExecutioner(p, opts, &executed)
+	action := e.action
+	e.action = func(args cnabprovider.ActionArguments) error {
+		resumed[args.Claim] = args.Resume
+		return action(args)
+	}
+
+	err = e.Execute(manifest.ActionInstall)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wordpress-mysql"}, executed, "only the failed dependency should be executed")
+	assert.True(t, resumed["wordpress-mysql"], "the failed dependency should be resumed")
+	assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "Skipping dependency mysql-storage, its last install completed")
+}
+
+func TestDependencyExecutioner_Execute_SharedInstallation(t *testing.T) {
+	p := NewTestPorter(t)
+	opts := BundleLifecycleOpts{DependencyInstallations: []string{"mysql=shared-mysql"}}
+	opts.Name = "wordpress"
+	err := opts.parseDependencyInstallations()
+	require.NoError(t, err)
+
+	err = p.Claims.Save(claim.Claim{
+		Name:       "shared-mysql",
+		Bundle:     &bundle.Bundle{Name: "mysql", Version: "0.1.0"},
+		Outputs:    map[string]interface{}{"connstr": "mysql://shared"},
+		Parameters: map[string]interface{}{"database": "shared"},
+	})
+	require.NoError(t, err)
+
+	var executed []string
+	e := newTestDependencyExecutioner(p, opts, &executed)
+	require.Len(t, e.deps, 1, "dependencies only required by the shared installation should not be managed by the parent")
+
+	err = e.Execute(manifest.ActionInstall)
+	require.NoError(t, err)
+	assert.Empty(t, executed, "the shared installation should not be modified")
+
+	mysql := e.requires["mysql"]
+	assert.Equal(t, map[string]interface{}{"connstr": "mysql://shared"}, mysql.outputs)
+	assert.Equal(t, map[string]interface{}{"database": "shared"}, mysql.parameters)
+	assert.Contains(t, string(mysql.cnabFileContents), `"name":"mysql"`)
+
+	c, err := p.Claims.Read("shared-mysql")
+	require.NoError(t, err)
+	dependents, err := claims.ReadDependents(c)
+	require.NoError(t, err)
+	assert.Equal(t, []claims.Dependent{{Installation: "wordpress", Alias: "mysql"}}, dependents)
+
+	// The parent installation records the binding when it is installed
+	args := cnabprovider.ActionArguments{}
+	e.ApplyDependencyMappings(&args)
+	assert.Equal(t, map[string]string{"mysql": "shared-mysql"}, args.SharedDependencies)
+	parent, err := claim.New("wordpress")
+	require.NoError(t, err)
+	require.NoError(t, claims.SetSharedDependencies(parent, args.SharedDependencies))
+	require.NoError(t, p.Claims.Save(*parent))
+
+	// The reference is remembered without specifying the binding again, and removed on uninstall
+	e = newTestDependencyExecutioner(p, BundleLifecycleOpts{sharedOptions: opts.sharedOptions}, &executed)
+	require.Len(t, e.deps, 1)
+	assert.Equal(t, "shared-mysql", e.deps[0].Installation)
+
+	err = e.Execute(manifest.ActionUninstall)
+	require.NoError(t, err)
+	assert.Empty(t, executed, "the shared installation should not be uninstalled")
+
+	c, err = p.Claims.Read("shared-mysql")
+	require.NoError(t, err)
+	dependents, err = claims.ReadDependents(c)
+	require.NoError(t, err)
+	assert.Empty(t, dependents)
+}
+
+func TestDependencyExecutioner_Execute_SharedInstallationMissing(t *testing.T) {
+	p := NewTestPorter(t)
+	opts := BundleLifecycleOpts{sharedDependencies: map[string]string{"mysql": "shared-mysql"}}
+	opts.Name = "wordpress"
+
+	var executed []string
+	e := newTestDependencyExecutioner(p, opts, &executed)
+	err := e.Execute(manifest.ActionInstall)
+	require.EqualError(t, err, "dependency mysql is bound to the installation shared-mysql, which does not exist")
+}
+
+func TestDependencyExecutioner_BindSharedDependencies_InvalidAlias(t *testing.T) {
+	p := NewTestPorter(t)
+	e := newDependencyExecutioner(p.Porter)
+	e.parentOpts.sharedDependencies = map[string]string{"db": "shared-mysql"}
+
+	err := e.bindSharedDependencies()
+	require.EqualError(t, err, "invalid --dependency-installation db, db is not a dependency of the bundle")
+}
+
+func TestDependencyExecutioner_ApplyDependencyParameters(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestCredentials.SecretsStore = secrets.NewSecretStore(&host.SecretStore{})
+	err := p.TestCredentials.Save(credentials.CredentialSet{
+		Name: "mycreds",
+		Credentials: []credentials.CredentialStrategy{
+			{Name: "token", Source: credentials.Source{Key: "value", Value: "topsecret"}},
+		},
+	})
+	require.NoError(t, err)
+
+	e := newDependencyExecutioner(p.Porter)
+	e.parentOpts.CredentialIdentifiers = []string{"mycreds"}
+	e.parentOpts.combinedParameters = map[string]string{"region": "eastus"}
+
+	stringDef := &definition.Schema{Type: "string"}
+	e.bundle = &bundle.Bundle{
+		Name: "wordpress",
+		Parameters: map[string]bundle.Parameter{
+			"region": {Definition: "region"},
+			"size":   {Definition: "size"},
+		},
+		Definitions: map[string]*definition.Schema{
+			"region": stringDef,
+			"size":   {Type: "string", Default: "small"},
+		},
+		Custom: map[string]interface{}{
+			extensions.DependencyParametersKey: extensions.DependencyParameters{
+				"mysql": {
+					"location": "{{ bundle.parameters.region }}",
+					"tier":     "{{ bundle.parameters.size }}",
+					"password": "{{ bundle.credentials.token }}",
+					"database": "wordpress",
+				},
+			},
+		},
+	}
+
+	storage := &queuedDependency{
+		bundle: &bundle.Bundle{
+			Name:        "storage",
+			Parameters:  map[string]bundle.Parameter{"location": {Definition: "string"}},
+			Definitions: map[string]*definition.Schema{"string": stringDef},
+		},
+	}
+	storage.Alias = "mysql-storage"
+	mysql := &queuedDependency{
+		// Set with --param mysql#database=mydb
+		Parameters: map[string]string{"database": "mydb"},
+		bundle: &bundle.Bundle{
+			Name: "mysql",
+			Parameters: map[string]bundle.Parameter{
+				"location": {Definition: "string"},
+				"tier":     {Definition: "string"},
+				"password": {Definition: "string"},
+				"database": {Definition: "string"},
+			},
+			Definitions: map[string]*definition.Schema{"string": stringDef},
+			Custom: map[string]interface{}{
+				extensions.DependencyParametersKey: extensions.DependencyParameters{
+					"storage": {"location": "{{ bundle.parameters.location }}"},
+				},
+			},
+		},
+		requires: map[string]*queuedDependency{"storage": storage},
+	}
+	mysql.Alias = "mysql"
+	e.deps = []*queuedDependency{storage, mysql}
+	e.requires = map[string]*queuedDependency{"mysql": mysql}
+
+	err = e.applyDependencyParameters()
+	require.NoError(t, err)
+
+	wantParams := map[string]string{
+		"location": "eastus",
+		"tier":     "small",
+		"password": "topsecret",
+		"database": "mydb",
+	}
+	assert.Equal(t, wantParams, mysql.Parameters, "the parent's parameters and credentials should be available to the templates, and --param should take precedence")
+	assert.Equal(t, map[string]string{"location": "eastus"}, storage.Parameters, "dependencies should pass their resolved parameters to their own dependencies")
+}
+
+func TestDependencyExecutioner_ApplyDependencyParameters_Invalid(t *testing.T) {
+	testcases := []struct {
+		name    string
+		params  map[string]string
+		wantErr string
+	}{
+		{"undefined parameter", map[string]string{"nope": "value"},
+			"invalid dependencies.mysql.parameters entry, nope is not a parameter defined in that bundle"},
+		{"missing variable", map[string]string{"database": "{{ bundle.parameters.nope }}"},
+			"could not evaluate the dependencies.mysql.parameters.database entry in bundle wordpress: Missing variable \"nope\""},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewTestPorter(t)
+			e := newDependencyExecutioner(p.Porter)
+			e.bundle = &bundle.Bundle{
+				Name: "wordpress",
+				Custom: map[string]interface{}{
+					extensions.DependencyParametersKey: extensions.DependencyParameters{"mysql": tc.params},
+				},
+			}
+			mysql := &queuedDependency{
+				bundle: &bundle.Bundle{
+					Name:       "mysql",
+					Parameters: map[string]bundle.Parameter{"database": {Definition: "string"}},
+				},
+			}
+			mysql.Alias = "mysql"
+			e.deps = []*queuedDependency{mysql}
+			e.requires = map[string]*queuedDependency{"mysql": mysql}
+
+			err := e.applyDependencyParameters()
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestDependencyExecutioner_DependencyCredentials(t *testing.T) {
+	p := NewTestPorter(t)
+	opts := BundleLifecycleOpts{}
+	opts.Name = "wordpress"
+	opts.CredentialIdentifiers = []string{"kube", "mysql#dbadmin", "mysql-storage#storage"}
+	opts.parseDependencyCredentials()
+	assert.Equal(t, []string{"kube"}, opts.CredentialIdentifiers, "forwarded credential sets should not be used by the parent")
+
+	var executed []string
+	e := newTestDependencyExecutioner(p, opts, &executed)
+	require.NoError(t, e.validateDependencyCredentials())
+	assert.Equal(t, []string{"kube", "dbadmin"}, e.dependencyCredentials("mysql"))
+	assert.Equal(t, []string{"kube", "storage"}, e.dependencyCredentials("mysql-storage"))
+
+	e.parentOpts.dependencyCredentials["redis"] = []string{"cache"}
+	err := e.validateDependencyCredentials()
+	require.EqualError(t, err, "invalid --cred redis#cache, redis is not a dependency of the bundle")
+}
@@ -0,0 +1,232 @@
+package porter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	configadapter "get.porter.sh/porter/pkg/cnab/config-adapter"
+	"get.porter.sh/porter/pkg/cnab/extensions"
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/printer"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/pkg/errors"
+)
+
+// DependencyTreeOptions are the options that may be specified when printing
+// the dependency tree of a bundle.
+type DependencyTreeOptions struct {
+	bundleFileOptions
+	BundlePullOptions
+	printer.PrintOptions
+}
+
+// PrintableDependency is a dependency in the resolved dependency tree of a bundle.
+type PrintableDependency struct {
+	// Alias is the name of the dependency in the bundle that requires it.
+	Alias string `json:"alias" yaml:"alias"`
+
+	// Bundle is the requested bundle, for example REGISTRY/NAME:TAG.
+	Bundle string `json:"bundle" yaml:"bundle"`
+
+	// Versions are the requested version ranges.
+	Versions []string `json:"versions,omitempty" yaml:"versions,omitempty"`
+
+	// AllowPrereleases specifies if prerelease versions can satisfy the requested version ranges.
+	AllowPrereleases bool `json:"prereleases,omitempty" yaml:"prereleases,omitempty"`
+
+	// Tag is the resolved tag of the dependency.
+	Tag string `json:"tag" yaml:"tag"`
+
+	// Digest is the digest of the resolved bundle definition.
+	Digest string `json:"digest" yaml:"digest"`
+
+	// Dependencies are the dependencies of the dependency.
+	Dependencies []PrintableDependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+}
+
+func (o *DependencyTreeOptions) Validate(cxt *context.Context) error {
+	err := o.ParseFormat()
+	if err != nil {
+		return err
+	}
+
+	if o.Tag != "" {
+		// Ignore anything set based on the bundle directory we are in, go off of the tag
+		o.File = ""
+		o.CNABFile = ""
+
+		return o.validateTag()
+	}
+
+	err = o.bundleFileOptions.Validate(cxt)
+	if err != nil {
+		return err
+	}
+
+	if o.File == "" && o.CNABFile == "" {
+		return errors.New("could not find porter.yaml in the current directory, make sure you are in the right directory or specify the bundle with --file, --cnab-file or --tag")
+	}
+
+	return nil
+}
+
+// PrintDependencyTree resolves the dependencies of a bundle, including the
+// dependencies of its dependencies, and prints the resolved tree.
+func (p *Porter) PrintDependencyTree(opts DependencyTreeOptions) error {
+	tree, err := p.ResolveDependencyTree(opts)
+	if err != nil {
+		return err
+	}
+	if tree == nil {
+		tree = []PrintableDependency{}
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		return printer.PrintJson(p.Out, tree)
+	case printer.FormatYaml:
+		return printer.PrintYaml(p.Out, tree)
+	case printer.FormatTable:
+		if len(tree) == 0 {
+			fmt.Fprintln(p.Out, "The bundle does not have any dependencies")
+			return nil
+		}
+		return p.printDependencyTreeTable(tree)
+	default:
+		return fmt.Errorf("invalid format: %s", opts.Format)
+	}
+}
+
+// ResolveDependencyTree resolves the dependencies of the bundle defined by the options.
+func (p *Porter) ResolveDependencyTree(opts DependencyTreeOptions) ([]PrintableDependency, error) {
+	bun, locks, err := p.loadDependencyTreeBundle(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := BundleResolver{
+		Cache:    p.Cache,
+		Registry: p.Registry,
+	}
+	solver := &extensions.DependencySolver{Locks: locks}
+	graph, err := solver.ResolveDependencyGraph(bun, func(tag string) (*bundle.Bundle, error) {
+		pullOpts := BundlePullOptions{
+			Tag:              tag,
+			InsecureRegistry: opts.InsecureRegistry,
+			Force:            opts.Force,
+		}
+		return pullDependencyBundle(resolver, p.CNAB, pullOpts, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildDependencyTree(graph.Root)
+}
+
+// loadDependencyTreeBundle loads the bundle definition and its locked dependency versions,
+// from the porter manifest and porter.lock, a bundle.json file or a tag.
+func (p *Porter) loadDependencyTreeBundle(opts DependencyTreeOptions) (*bundle.Bundle, extensions.DependencyLocks, error) {
+	if opts.File != "" {
+		err := p.LoadManifestFrom(opts.File)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		lockFile, err := p.readLockFile(p.getLockFilePath())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		converter := configadapter.NewManifestConverter(p.Context, p.Manifest, nil, nil)
+		return converter.ToBundle(), lockFile.Dependencies, nil
+	}
+
+	bunPath := opts.CNABFile
+	if opts.Tag != "" {
+		var err error
+		bunPath, _, err = p.PullBundle(opts.BundlePullOptions)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "unable to pull bundle %s", opts.Tag)
+		}
+	}
+
+	bun, err := p.CNAB.LoadBundle(bunPath, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	locks, err := extensions.ReadDependencyLocks(bun)
+	return bun, locks, err
+}
+
+// buildDependencyTree converts the dependencies of a node in the dependency graph
+// into a tree, sorted by alias.
+func buildDependencyTree(node *extensions.DependencyNode) ([]PrintableDependency, error) {
+	if len(node.Requires) == 0 {
+		return nil, nil
+	}
+
+	deps, err := extensions.ReadDependencies(node.Bundle)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the dependencies of %s", node.Alias)
+	}
+
+	tree := make([]PrintableDependency, 0, len(node.Requires))
+	for alias, depNode := range node.Requires {
+		requested := deps.Requires[alias]
+		dep := PrintableDependency{
+			Alias:  alias,
+			Bundle: requested.Bundle,
+			Tag:    depNode.Tag,
+			Digest: depNode.Digest,
+		}
+		if requested.Version != nil {
+			dep.Versions = requested.Version.Ranges
+			dep.AllowPrereleases = requested.Version.AllowPrereleases
+		}
+
+		dep.Dependencies, err = buildDependencyTree(depNode)
+		if err != nil {
+			return nil, err
+		}
+
+		tree = append(tree, dep)
+	}
+
+	sort.Slice(tree, func(i, j int) bool {
+		return tree[i].Alias < tree[j].Alias
+	})
+	return tree, nil
+}
+
+type dependencyTreeRow struct {
+	depth int
+	dep   PrintableDependency
+}
+
+// printDependencyTreeTable prints each dependency on its own row, indenting
+// the alias of a dependency under the bundle that requires it.
+func (p *Porter) printDependencyTreeTable(tree []PrintableDependency) error {
+	var rows []dependencyTreeRow
+	var flatten func(deps []PrintableDependency, depth int)
+	flatten = func(deps []PrintableDependency, depth int) {
+		for _, dep := range deps {
+			rows = append(rows, dependencyTreeRow{depth: depth, dep: dep})
+			flatten(dep.Dependencies, depth+1)
+		}
+	}
+	flatten(tree, 0)
+
+	printDependencyRow := func(v interface{}) []interface{} {
+		row, ok := v.(dependencyTreeRow)
+		if !ok {
+			return nil
+		}
+
+		alias := strings.Repeat("  ", row.depth) + row.dep.Alias
+		return []interface{}{alias, row.dep.Bundle, strings.Join(row.dep.Versions, ", "), row.dep.Tag, row.dep.Digest}
+	}
+	return printer.PrintTable(p.Out, rows, printDependencyRow, "ALIAS", "BUNDLE", "VERSIONS", "TAG", "DIGEST")
+}
@@ -0,0 +1,97 @@
+package porter
+
+import (
+	"testing"
+
+	"get.porter.sh/porter/pkg/cnab/extensions"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/printer"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPorter_PrintDependencyTree(t *testing.T) {
+	testcases := []struct {
+		format     printer.Format
+		wantOutput []string
+	}{
+		{printer.FormatTable, []string{
+			"ALIAS   BUNDLE                   VERSIONS   TAG                      DIGEST",
+			"mysql   getporter/mysql:v0.1.1              getporter/mysql:v0.1.1   sha256:",
+		}},
+		{printer.FormatJson, []string{`"alias": "mysql"`, `"tag": "getporter/mysql:v0.1.1"`, `"digest": "sha256:`}},
+		{printer.FormatYaml, []string{"- alias: mysql", "  tag: getporter/mysql:v0.1.1", "  digest: sha256:"}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(string(tc.format), func(t *testing.T) {
+			p := NewTestPorter(t)
+			p.TestConfig.SetupPorterHome()
+			p.TestConfig.TestContext.AddTestFile("../../build/testdata/bundles/wordpress/porter.yaml", config.Name)
+			p.CNAB = NewTestCNABProvider()
+			p.Cache = &mockCache{
+				findBundleMock: func(tag string) (string, string, bool, error) {
+					return "/cache/" + tag + "/bundle.json", "", true, nil
+				},
+			}
+
+			opts := DependencyTreeOptions{}
+			opts.File = config.Name
+			opts.RawFormat = string(tc.format)
+			err := opts.Validate(p.Context)
+			require.NoError(t, err)
+
+			err = p.PrintDependencyTree(opts)
+			require.NoError(t, err)
+
+			gotOutput := p.TestConfig.TestContext.GetOutput()
+			for _, line := range tc.wantOutput {
+				assert.Contains(t, gotOutput, line)
+			}
+		})
+	}
+}
+
+func TestBuildDependencyTree(t *testing.T) {
+	root := &extensions.DependencyNode{
+		DependencyLock: extensions.DependencyLock{Alias: "app"},
+	}
+	storage := &extensions.DependencyNode{
+		DependencyLock: extensions.DependencyLock{Alias: "db-storage", Tag: "getporter/storage:v1.0.0", Digest: "sha256:storage"},
+	}
+	db := &extensions.DependencyNode{
+		DependencyLock: extensions.DependencyLock{Alias: "db", Tag: "getporter/mysql:5.7.12", Digest: "sha256:mysql"},
+		Requires:       map[string]*extensions.DependencyNode{"storage": storage},
+	}
+	root.Requires = map[string]*extensions.DependencyNode{"db": db}
+
+	root.Bundle = &bundle.Bundle{Name: "app", Custom: map[string]interface{}{
+		extensions.DependenciesKey: extensions.Dependencies{Requires: map[string]extensions.Dependency{
+			"db": {Bundle: "getporter/mysql", Version: &extensions.DependencyVersion{Ranges: []string{"5.7.x"}, AllowPrereleases: true}},
+		}},
+	}}
+	db.Bundle = &bundle.Bundle{Name: "mysql", Custom: map[string]interface{}{
+		extensions.DependenciesKey: extensions.Dependencies{Requires: map[string]extensions.Dependency{
+			"storage": {Bundle: "getporter/storage:v1.0.0"},
+		}},
+	}}
+
+	tree, err := buildDependencyTree(root)
+	require.NoError(t, err)
+
+	wantTree := []PrintableDependency{
+		{
+			Alias:            "db",
+			Bundle:           "getporter/mysql",
+			Versions:         []string{"5.7.x"},
+			AllowPrereleases: true,
+			Tag:              "getporter/mysql:5.7.12",
+			Digest:           "sha256:mysql",
+			Dependencies: []PrintableDependency{
+				{Alias: "storage", Bundle: "getporter/storage:v1.0.0", Tag: "getporter/storage:v1.0.0", Digest: "sha256:storage"},
+			},
+		},
+	}
+	assert.Equal(t, wantTree, tree)
+}
@@ -17,9 +17,11 @@ import (
 	"get.porter.sh/porter/pkg/manifest"
 	"get.porter.sh/porter/pkg/mixin"
 	mixinprovider "get.porter.sh/porter/pkg/mixin/provider"
+	"get.porter.sh/porter/pkg/parameters"
 	"get.porter.sh/porter/pkg/plugins"
 	"get.porter.sh/porter/pkg/secrets"
 	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/bundle/definition"
 	cnabcreds "github.com/cnabio/cnab-go/credentials"
 	"github.com/cnabio/cnab-go/secrets/host"
 	"github.com/docker/cnab-to-oci/relocation"
@@ -32,6 +34,7 @@ type TestPorter struct {
 	*Porter
 	TestConfig      *config.TestConfig
 	TestCredentials *credentials.TestCredentialProvider
+	TestParameters  *parameters.TestParameterProvider
 
 	// original directory where the test was being executed
 	TestDir string
@@ -44,6 +47,7 @@ type TestPorter struct {
 func NewTestPorter(t *testing.T) *TestPorter {
 	tc := config.NewTestConfig(t)
 	testCredentials := credentials.NewTestCredentialProvider(t, tc)
+	testParameters := parameters.NewTestParameterProvider(t, tc)
 	p := New()
 	p.Config = tc.Config
 	p.Mixins = &mixin.TestMixinProvider{}
@@ -52,12 +56,14 @@ func NewTestPorter(t *testing.T) *TestPorter {
 	p.Builder = NewTestBuildProvider()
 	p.Claims = claims.NewTestClaimProvider()
 	p.Credentials = testCredentials
+	p.Parameters = testParameters
 	p.CNAB = cnabprovider.NewRuntime(tc.Config, p.Claims, p.Credentials)
 
 	return &TestPorter{
 		Porter:          p,
 		TestConfig:      tc,
 		TestCredentials: &testCredentials,
+		TestParameters:  &testParameters,
 	}
 }
 
@@ -68,6 +74,7 @@ func (p *TestPorter) SetupIntegrationTest() {
 	p.Builder = buildprovider.NewDockerBuilder(p.Context)
 	p.Mixins = mixinprovider.NewFileSystem(p.Config)
 	p.TestCredentials.SecretsStore = secrets.NewSecretStore(&host.SecretStore{})
+	p.TestParameters.SecretsStore = secrets.NewSecretStore(&host.SecretStore{})
 
 	homeDir := p.UseFilesystem()
 	p.TestConfig.SetupIntegrationTest(homeDir)
@@ -160,6 +167,23 @@ func (t *TestCNABProvider) LoadBundle(bundleFile string, insecure bool) (*bundle
 				},
 			},
 		},
+		Definitions: definition.Definitions{
+			"region": &definition.Schema{
+				Type: "string",
+			},
+			"porter-debug": &definition.Schema{
+				Type:    "boolean",
+				Default: false,
+			},
+		},
+		Parameters: map[string]bundle.Parameter{
+			"region": {
+				Definition: "region",
+			},
+			"porter-debug": {
+				Definition: "porter-debug",
+			},
+		},
 	}
 	return b, nil
 }
@@ -180,6 +204,10 @@ func (t *TestCNABProvider) Uninstall(arguments cnabprovider.ActionArguments) err
 	return nil
 }
 
+func (t *TestCNABProvider) Status(arguments cnabprovider.ActionArguments) (map[string]interface{}, error) {
+	return nil, nil
+}
+
 type TestBuildProvider struct{}
 
 func NewTestBuildProvider() *TestBuildProvider {
@@ -0,0 +1,79 @@
+package porter
+
+import (
+	"fmt"
+	"time"
+
+	"get.porter.sh/porter/pkg/claims"
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/printer"
+	dtprinter "github.com/carolynvs/datetime-printer"
+	"github.com/pkg/errors"
+)
+
+// HistoryOptions represent options for showing the history of a bundle instance.
+type HistoryOptions struct {
+	sharedOptions
+	printer.PrintOptions
+}
+
+// Validate prepares for the history action and validates the args/options.
+func (o *HistoryOptions) Validate(args []string, cxt *context.Context) error {
+	// Ensure only one argument exists (instance name) if args length non-zero
+	err := o.sharedOptions.validateInstanceName(args)
+	if err != nil {
+		return err
+	}
+
+	err = o.sharedOptions.defaultBundleFiles(cxt)
+	if err != nil {
+		return err
+	}
+
+	return o.ParseFormat()
+}
+
+// ShowInstanceHistory shows every action that was executed against a bundle instance,
+// from oldest to newest.
+func (p *Porter) ShowInstanceHistory(opts HistoryOptions) error {
+	err := p.applyDefaultOptions(&opts.sharedOptions)
+	if err != nil {
+		return err
+	}
+
+	runs, err := p.Claims.ReadHistory(opts.Name)
+	if err != nil {
+		return errors.Wrapf(err, "could not read the history of bundle instance %s", opts.Name)
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		return printer.PrintJson(p.Out, runs)
+	case printer.FormatYaml:
+		return printer.PrintYaml(p.Out, runs)
+	case printer.FormatTable:
+		if len(runs) == 0 {
+			fmt.Fprintf(p.Out, "No history recorded for bundle instance %s\n", opts.Name)
+			return nil
+		}
+
+		// have every row use the same "now" starting ... NOW!
+		now := time.Now()
+		tp := dtprinter.DateTimePrinter{
+			Now: func() time.Time { return now },
+		}
+
+		printRunRow :=
+			func(v interface{}) []interface{} {
+				run, ok := v.(claims.Run)
+				if !ok {
+					return nil
+				}
+				return []interface{}{run.ID, tp.Format(run.Timestamp), run.Action, run.Status, run.BundleVersion, run.User, run.PorterVersion}
+			}
+		return printer.PrintTable(p.Out, runs, printRunRow,
+			"ID", "TIMESTAMP", "ACTION", "STATUS", "BUNDLE VERSION", "USER", "PORTER VERSION")
+	default:
+		return fmt.Errorf("invalid format: %s", opts.Format)
+	}
+}
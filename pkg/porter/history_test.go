@@ -0,0 +1,68 @@
+package porter
+
+import (
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/claims"
+	"get.porter.sh/porter/pkg/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPorter_ShowInstanceHistory(t *testing.T) {
+	testcases := []struct {
+		name         string
+		format       printer.Format
+		wantContains []string
+	}{
+		{name: "table", format: printer.FormatTable, wantContains: []string{
+			"ID        TIMESTAMP    ACTION    STATUS    BUNDLE VERSION   USER    PORTER VERSION",
+			"01E2ZZ0   2020-03-01   install   success   0.1.0            sally   v0.22.0",
+			"01E2ZZ1   2020-03-02   upgrade   failure   0.2.0            bob     v0.23.0",
+		}},
+		{name: "json", format: printer.FormatJson, wantContains: []string{`"installation": "mybuns"`, `"region": "eastus"`}},
+		{name: "yaml", format: printer.FormatYaml, wantContains: []string{"installation: mybuns", "region: eastus"}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewTestPorter(t)
+
+			runs := []claims.Run{
+				{ID: "01E2ZZ1", Installation: "mybuns", Action: "upgrade", Status: "failure", BundleVersion: "0.2.0",
+					Timestamp: time.Date(2020, time.March, 2, 1, 2, 3, 4, time.UTC), User: "bob", PorterVersion: "v0.23.0"},
+				{ID: "01E2ZZ0", Installation: "mybuns", Action: "install", Status: "success", BundleVersion: "0.1.0",
+					Timestamp: time.Date(2020, time.March, 1, 1, 2, 3, 4, time.UTC), User: "sally", PorterVersion: "v0.22.0",
+					Parameters: map[string]interface{}{"region": "eastus"}},
+			}
+			for _, run := range runs {
+				require.NoError(t, p.Claims.SaveRun(run))
+			}
+
+			opts := HistoryOptions{}
+			opts.Name = "mybuns"
+			opts.Format = tc.format
+
+			err := p.ShowInstanceHistory(opts)
+			require.NoError(t, err)
+
+			gotOutput := p.TestConfig.TestContext.GetOutput()
+			for _, want := range tc.wantContains {
+				assert.Contains(t, gotOutput, want)
+			}
+		})
+	}
+}
+
+func TestPorter_ShowInstanceHistory_NoHistory(t *testing.T) {
+	p := NewTestPorter(t)
+
+	opts := HistoryOptions{}
+	opts.Name = "mybuns"
+	opts.Format = printer.FormatTable
+
+	err := p.ShowInstanceHistory(opts)
+	require.NoError(t, err)
+	assert.Equal(t, "No history recorded for bundle instance mybuns\n", p.TestConfig.TestContext.GetOutput())
+}
@@ -32,6 +32,11 @@ func (p *Porter) InstallBundle(opts InstallOptions) error {
 		return err
 	}
 
+	err = p.promptForParameters(&opts.BundleLifecycleOpts, string(manifest.ActionInstall))
+	if err != nil {
+		return err
+	}
+
 	deperator := newDependencyExecutioner(p)
 	err = deperator.Prepare(opts.BundleLifecycleOpts, p.CNAB.Install)
 	if err != nil {
@@ -43,6 +48,14 @@ func (p *Porter) InstallBundle(opts InstallOptions) error {
 		return err
 	}
 
+	args := opts.ToActionArgs(deperator)
+	if opts.Resume {
+		args.Resume, err = p.resumeInstallation(opts.Name, manifest.ActionInstall)
+		if err != nil {
+			return err
+		}
+	}
+
 	fmt.Fprintf(p.Out, "installing %s...\n", opts.Name)
-	return p.CNAB.Install(opts.ToActionArgs(deperator))
+	return p.CNAB.Install(args)
 }
@@ -162,6 +162,38 @@ func TestInstallOptions_combineParameters(t *testing.T) {
 	assert.Equal(t, wantParams, gotParams)
 }
 
+func TestInstallOptions_combineParameters_YAML(t *testing.T) {
+	p := NewTestPorter(t)
+	p.FileSystem = &afero.Afero{Fs: afero.NewOsFs()}
+
+	opts := InstallOptions{
+		BundleLifecycleOpts{
+			sharedOptions: sharedOptions{
+				ParamFiles: []string{
+					"testdata/install/base-params.txt",
+					"testdata/install/dev-params.yaml",
+				},
+				Params: []string{"A=true"},
+			},
+		},
+	}
+
+	err := opts.validateParams(p.Context)
+	require.NoError(t, err)
+
+	gotParams := opts.combineParameters()
+
+	wantParams := map[string]string{
+		"A": "true",
+		"B": "2",
+		"C": "3",
+		"D": "blue",
+		"F": `{"size":"small"}`,
+	}
+
+	assert.Equal(t, wantParams, gotParams)
+}
+
 func TestInstallOptions_validateDriver(t *testing.T) {
 	testcases := []struct {
 		name       string
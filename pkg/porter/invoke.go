@@ -43,6 +43,11 @@ func (p *Porter) InvokeBundle(opts InvokeOptions) error {
 		return err
 	}
 
+	err = p.promptForParameters(&opts.BundleLifecycleOpts, opts.Action)
+	if err != nil {
+		return err
+	}
+
 	deperator := newDependencyExecutioner(p)
 	err = deperator.Prepare(opts.BundleLifecycleOpts, func(args cnabprovider.ActionArguments) error {
 		return p.CNAB.Invoke(opts.Action, args)
@@ -1,8 +1,11 @@
 package porter
 
 import (
+	"strings"
+
 	cnabprovider "get.porter.sh/porter/pkg/cnab/provider"
 	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/parameters"
 	"github.com/cnabio/cnab-go/bundle"
 	"github.com/pkg/errors"
 )
@@ -10,6 +13,28 @@ import (
 type BundleLifecycleOpts struct {
 	sharedOptions
 	BundlePullOptions
+
+	// SkipDependencies prevents the action from being executed against the bundle's dependencies.
+	SkipDependencies bool
+
+	// DependencyInstallations is the unparsed list of ALIAS=INSTALLATION bindings of
+	// dependencies to existing installations set on the command line.
+	DependencyInstallations []string
+
+	// sharedDependencies is the parsed set of DependencyInstallations, keyed by the dependency alias.
+	sharedDependencies map[string]string
+
+	// dependencyCredentials are the credential sets forwarded to a dependency with --cred ALIAS#NAME,
+	// keyed by the dependency alias.
+	dependencyCredentials map[string][]string
+
+	// Resume skips the steps that completed in the last failed run of the action.
+	// Only install and upgrade support resuming.
+	Resume bool
+
+	// Interactive prompts for the value of each parameter that was not specified.
+	// Only install, upgrade and invoke support prompting.
+	Interactive bool
 }
 
 func (o *BundleLifecycleOpts) Validate(args []string, cxt *context.Context) error {
@@ -17,6 +42,14 @@ func (o *BundleLifecycleOpts) Validate(args []string, cxt *context.Context) erro
 	if err != nil {
 		return err
 	}
+
+	err = o.parseDependencyInstallations()
+	if err != nil {
+		return err
+	}
+
+	o.parseDependencyCredentials()
+
 	if o.Tag != "" {
 		// Ignore anything set based on the bundle directory we are in, go off of the tag
 		o.File = ""
@@ -27,6 +60,36 @@ func (o *BundleLifecycleOpts) Validate(args []string, cxt *context.Context) erro
 	return nil
 }
 
+// parseDependencyInstallations parses the ALIAS=INSTALLATION bindings in DependencyInstallations.
+func (o *BundleLifecycleOpts) parseDependencyInstallations() error {
+	o.sharedDependencies = make(map[string]string, len(o.DependencyInstallations))
+	for _, binding := range o.DependencyInstallations {
+		parts := strings.SplitN(binding, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return errors.Errorf("invalid --dependency-installation %s, must be in ALIAS=INSTALLATION format", binding)
+		}
+
+		o.sharedDependencies[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return nil
+}
+
+// parseDependencyCredentials separates the credential sets forwarded to dependencies,
+// in the form ALIAS#NAME, from the credential sets used by the bundle.
+func (o *BundleLifecycleOpts) parseDependencyCredentials() {
+	o.dependencyCredentials = make(map[string][]string)
+	creds := make([]string, 0, len(o.CredentialIdentifiers))
+	for _, cred := range o.CredentialIdentifiers {
+		parts := strings.SplitN(cred, "#", 2)
+		if len(parts) == 2 {
+			o.dependencyCredentials[parts[0]] = append(o.dependencyCredentials[parts[0]], parts[1])
+		} else {
+			creds = append(creds, cred)
+		}
+	}
+	o.CredentialIdentifiers = creds
+}
+
 // ToActionArgs converts this instance of user-provided action options.
 func (o *BundleLifecycleOpts) ToActionArgs(deperator *dependencyExecutioner) cnabprovider.ActionArguments {
 	args := cnabprovider.ActionArguments{
@@ -51,6 +114,50 @@ func (o *BundleLifecycleOpts) ToActionArgs(deperator *dependencyExecutioner) cna
 	return args
 }
 
+// promptForParameters asks the user for the value of each parameter of the bundle,
+// that applies to the action and was not specified, when running interactively.
+func (p *Porter) promptForParameters(opts *BundleLifecycleOpts, action string) error {
+	if !opts.Interactive {
+		return nil
+	}
+
+	var bun *bundle.Bundle
+	if opts.CNABFile != "" {
+		b, err := p.CNAB.LoadBundle(opts.CNABFile, opts.Insecure)
+		if err != nil {
+			return err
+		}
+		bun = b
+	} else {
+		c, err := p.Claims.Read(opts.Name)
+		if err != nil {
+			return errors.Wrapf(err, "could not load the bundle of instance %s", opts.Name)
+		}
+		if c.Bundle == nil {
+			return errors.Errorf("the bundle of bundle instance %s was not recorded", opts.Name)
+		}
+		bun = c.Bundle
+	}
+
+	values, err := parameters.PromptForParameters(parameters.PromptOptions{
+		Bundle:    bun,
+		Action:    action,
+		Specified: opts.combinedParameters,
+		Ask:       p.askParameter,
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.combinedParameters == nil {
+		opts.combinedParameters = make(map[string]string, len(values))
+	}
+	for k, v := range values {
+		opts.combinedParameters[k] = v
+	}
+	return nil
+}
+
 // prepullBundleByTag handles calling the bundle pull operation and updating
 // the shared options like name and bundle file path. This is used by install, upgrade
 // and uninstall
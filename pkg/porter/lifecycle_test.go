@@ -4,8 +4,12 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/cnabio/cnab-go/claim"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	survey "gopkg.in/AlecAivazis/survey.v1"
 )
 
 func TestBundlePullUpdateOpts_bundleCached(t *testing.T) {
@@ -100,3 +104,68 @@ func TestInstallFromTagIgnoresCurrentBundle(t *testing.T) {
 
 	assert.Empty(t, installOpts.File, "The install should ignore the bundle in the current directory because we are installing from a tag")
 }
+
+func TestBundleLifecycleOpts_ParseDependencyInstallations(t *testing.T) {
+	opts := BundleLifecycleOpts{DependencyInstallations: []string{"mysql=shared-mysql", " redis = cache "}}
+	err := opts.parseDependencyInstallations()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"mysql": "shared-mysql", "redis": "cache"}, opts.sharedDependencies)
+
+	opts = BundleLifecycleOpts{DependencyInstallations: []string{"mysql"}}
+	err = opts.parseDependencyInstallations()
+	require.EqualError(t, err, "invalid --dependency-installation mysql, must be in ALIAS=INSTALLATION format")
+}
+
+func TestPromptForParameters(t *testing.T) {
+	p := NewTestPorter(t)
+
+	c, err := claim.New("mybuns")
+	require.NoError(t, err)
+	c.Bundle = &bundle.Bundle{
+		Name: "mybuns",
+		Definitions: definition.Definitions{
+			"string": &definition.Schema{Type: "string"},
+		},
+		Parameters: map[string]bundle.Parameter{
+			"region": {Definition: "string", Required: true},
+			"tag":    {Definition: "string", Required: true},
+		},
+	}
+	require.NoError(t, p.Claims.Save(*c))
+
+	var asked []string
+	p.askParameter = func(prompt survey.Prompt, response interface{}, validator survey.Validator) error {
+		asked = append(asked, prompt.(*survey.Input).Message)
+		*response.(*string) = "v1"
+		return nil
+	}
+
+	opts := BundleLifecycleOpts{}
+	opts.Name = "mybuns"
+	opts.combinedParameters = map[string]string{"region": "eastus"}
+
+	err = p.promptForParameters(&opts, "upgrade")
+	require.NoError(t, err)
+	assert.Empty(t, asked, "should not prompt unless running interactively")
+
+	opts.Interactive = true
+	err = p.promptForParameters(&opts, "upgrade")
+	require.NoError(t, err)
+	assert.Equal(t, []string{`Enter a value for parameter "tag"`}, asked, "only the parameters that were not specified should be prompted")
+	assert.Equal(t, map[string]string{"region": "eastus", "tag": "v1"}, opts.combinedParameters)
+}
+
+func TestPromptForParameters_BundleNotRecorded(t *testing.T) {
+	p := NewTestPorter(t)
+
+	c, err := claim.New("mybuns")
+	require.NoError(t, err)
+	require.NoError(t, p.Claims.Save(*c))
+
+	opts := BundleLifecycleOpts{}
+	opts.Name = "mybuns"
+	opts.Interactive = true
+
+	err = p.promptForParameters(&opts, "upgrade")
+	require.EqualError(t, err, "the bundle of bundle instance mybuns was not recorded")
+}
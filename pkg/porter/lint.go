@@ -0,0 +1,98 @@
+package porter
+
+import (
+	"fmt"
+
+	configadapter "get.porter.sh/porter/pkg/cnab/config-adapter"
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/linter"
+	"get.porter.sh/porter/pkg/manifest"
+	"get.porter.sh/porter/pkg/printer"
+	"github.com/pkg/errors"
+)
+
+// LintOptions are the options that may be specified when linting a bundle.
+type LintOptions struct {
+	bundleFileOptions
+	printer.PrintOptions
+}
+
+func (o *LintOptions) Validate(cxt *context.Context) error {
+	err := o.ParseFormat()
+	if err != nil {
+		return err
+	}
+
+	err = o.bundleFileOptions.Validate(cxt)
+	if err != nil {
+		return err
+	}
+
+	if o.File == "" {
+		return errors.New("could not find porter.yaml in the current directory, make sure you are in the right directory or specify the porter manifest with --file")
+	}
+
+	return nil
+}
+
+// Lint checks the bundle defined by the porter manifest against the default linter rules.
+func (p *Porter) Lint(opts LintOptions) (linter.Results, error) {
+	m, err := manifest.ReadManifest(p.Context, opts.File)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &linter.Input{Manifest: m}
+	for _, file := range m.Files {
+		input.AddFile(file.Location, file.Data)
+	}
+
+	converter := configadapter.NewManifestConverter(p.Context, m, nil, nil)
+	input.Bundle = converter.ToBundle()
+
+	return linter.New().Lint(input), nil
+}
+
+// PrintLintResults lints the bundle and prints the results. An error is returned
+// when any of the results are errors.
+func (p *Porter) PrintLintResults(opts LintOptions) error {
+	results, err := p.Lint(opts)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		err = printer.PrintJson(p.Out, results)
+	case printer.FormatYaml:
+		err = printer.PrintYaml(p.Out, results)
+	case printer.FormatPlaintext:
+		if len(results) > 0 {
+			fmt.Fprintln(p.Out, results)
+		}
+	case printer.FormatTable:
+		if len(results) == 0 {
+			fmt.Fprintln(p.Out, "No problems found")
+			break
+		}
+		err = printer.PrintTable(p.Out, results,
+			func(v interface{}) []interface{} {
+				r, ok := v.(linter.Result)
+				if !ok {
+					return nil
+				}
+				return []interface{}{r.Level, r.Code, r.Location, r.Message}
+			},
+			"LEVEL", "CODE", "LOCATION", "MESSAGE")
+	default:
+		return fmt.Errorf("invalid format: %s", opts.Format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if results.HasError() {
+		return errors.New("the bundle has lint errors")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package porter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"get.porter.sh/porter/pkg/linter"
+	"get.porter.sh/porter/pkg/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPorter_PrintLintResults(t *testing.T) {
+	t.Run("no problems", func(t *testing.T) {
+		p := NewTestPorter(t)
+		p.TestConfig.TestContext.AddTestFile("../manifest/testdata/simple.porter.yaml", "porter.yaml")
+
+		opts := LintOptions{
+			bundleFileOptions: bundleFileOptions{File: "porter.yaml"},
+			PrintOptions:      printer.PrintOptions{Format: printer.FormatTable},
+		}
+		err := p.PrintLintResults(opts)
+		require.NoError(t, err)
+		assert.Equal(t, "No problems found\n", p.TestConfig.TestContext.GetOutput())
+	})
+
+	t.Run("table", func(t *testing.T) {
+		p := NewTestPorter(t)
+		p.TestConfig.TestContext.AddTestDirectory("../linter/testdata", ".")
+
+		opts := LintOptions{
+			bundleFileOptions: bundleFileOptions{File: "porter.yaml"},
+			PrintOptions:      printer.PrintOptions{Format: printer.FormatTable},
+		}
+		err := p.PrintLintResults(opts)
+		require.EqualError(t, err, "the bundle has lint errors")
+
+		gotOutput := p.TestConfig.TestContext.GetOutput()
+		assert.Contains(t, gotOutput, "LEVEL     CODE         LOCATION          MESSAGE\n")
+		assert.Contains(t, gotOutput, "warning   porter-104   porter.yaml:3     the bundle tag getporter/lint does not specify a version")
+		assert.Contains(t, gotOutput, "error     porter-102   actions.yaml:2    step \"Status\" in the status action references the output missing")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		p := NewTestPorter(t)
+		p.TestConfig.TestContext.AddTestDirectory("../linter/testdata", ".")
+
+		opts := LintOptions{
+			bundleFileOptions: bundleFileOptions{File: "porter.yaml"},
+			PrintOptions:      printer.PrintOptions{Format: printer.FormatJson},
+		}
+		err := p.PrintLintResults(opts)
+		require.Error(t, err)
+
+		var results linter.Results
+		err = json.Unmarshal([]byte(p.TestConfig.TestContext.GetOutput()), &results)
+		require.NoError(t, err)
+		require.Len(t, results, 6)
+		assert.Equal(t, linter.Result{
+			Level:    linter.LevelWarning,
+			Code:     "porter-104",
+			Title:    "Unversioned tag",
+			Message:  "the bundle tag getporter/lint does not specify a version, for example docker.io/getporter/lint:v0.1.0",
+			Location: linter.Location{File: "porter.yaml", Line: 3},
+		}, results[0])
+	})
+}
+
+func TestLintOptions_Validate(t *testing.T) {
+	p := NewTestPorter(t)
+
+	opts := LintOptions{
+		bundleFileOptions: bundleFileOptions{File: "porter.yaml"},
+		PrintOptions:      printer.PrintOptions{RawFormat: "html"},
+	}
+	err := opts.Validate(p.Context)
+	assert.EqualError(t, err, "invalid format: html")
+}
@@ -0,0 +1,145 @@
+package porter
+
+import (
+	"fmt"
+	"path/filepath"
+
+	configadapter "get.porter.sh/porter/pkg/cnab/config-adapter"
+	"get.porter.sh/porter/pkg/cnab/extensions"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/context"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// LockFile is the porter.lock file, which records the versions of a bundle's
+// dependencies so that building the bundle is reproducible.
+type LockFile struct {
+	// Dependencies is the resolved version of every dependency, including
+	// dependencies of dependencies, in the order that they are installed.
+	Dependencies extensions.DependencyLocks `yaml:"dependencies"`
+}
+
+// DependencyUpdateOptions are the options that may be specified when updating
+// the porter.lock file.
+type DependencyUpdateOptions struct {
+	bundleFileOptions
+	InsecureRegistry bool
+}
+
+func (o *DependencyUpdateOptions) Validate(cxt *context.Context) error {
+	err := o.bundleFileOptions.Validate(cxt)
+	if err != nil {
+		return err
+	}
+
+	if o.File == "" {
+		return errors.New("could not find porter.yaml in the current directory, make sure you are in the right directory or specify the porter manifest with --file")
+	}
+
+	return nil
+}
+
+// UpdateDependencies resolves the dependencies of the bundle again, ignoring
+// any previously locked versions, and saves the results to porter.lock.
+func (p *Porter) UpdateDependencies(opts DependencyUpdateOptions) error {
+	err := p.LoadManifestFrom(opts.File)
+	if err != nil {
+		return err
+	}
+
+	locks, err := p.lockDependencies(BundlePullOptions{InsecureRegistry: opts.InsecureRegistry, Force: true}, true)
+	if err != nil {
+		return err
+	}
+
+	for _, lock := range locks {
+		fmt.Fprintf(p.Out, "Locked dependency %s to %s\n", lock.Alias, lock.Tag)
+	}
+	return nil
+}
+
+// lockDependencies resolves the dependencies of the bundle defined in the porter
+// manifest and records them in porter.lock. Versions already recorded in porter.lock
+// are used when they still satisfy the manifest, unless update is specified.
+func (p *Porter) lockDependencies(pullOpts BundlePullOptions, update bool) (extensions.DependencyLocks, error) {
+	graph, err := p.resolveDependencyGraph(pullOpts, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.writeDependencyLocks(graph)
+}
+
+// writeDependencyLocks records the versions of the dependencies in the resolved graph in porter.lock.
+func (p *Porter) writeDependencyLocks(graph *extensions.DependencyGraph) (extensions.DependencyLocks, error) {
+	if graph == nil {
+		return nil, nil
+	}
+
+	locks := graph.Locks()
+	err := p.writeLockFile(p.getLockFilePath(), LockFile{Dependencies: locks})
+	return locks, err
+}
+
+// resolveDependencyGraph resolves the dependencies of the bundle defined in the porter
+// manifest, using the versions recorded in porter.lock unless update is specified.
+// Returns nil when the bundle doesn't have any dependencies.
+func (p *Porter) resolveDependencyGraph(pullOpts BundlePullOptions, update bool) (*extensions.DependencyGraph, error) {
+	if len(p.Manifest.Dependencies) == 0 {
+		return nil, nil
+	}
+
+	solver := &extensions.DependencySolver{}
+	if !update {
+		lockFile, err := p.readLockFile(p.getLockFilePath())
+		if err != nil {
+			return nil, err
+		}
+		solver.Locks = lockFile.Dependencies
+	}
+
+	converter := configadapter.NewManifestConverter(p.Context, p.Manifest, nil, nil)
+	bun := converter.ToBundle()
+
+	resolver := BundleResolver{
+		Cache:    p.Cache,
+		Registry: p.Registry,
+	}
+	return solver.ResolveDependencyGraph(bun, func(tag string) (*bundle.Bundle, error) {
+		pullOpts.Tag = tag
+		return pullDependencyBundle(resolver, p.CNAB, pullOpts, true)
+	})
+}
+
+func (p *Porter) getLockFilePath() string {
+	return filepath.Join(filepath.Dir(p.Manifest.ManifestPath), config.LockName)
+}
+
+// readLockFile reads porter.lock, returning an empty lock file when it doesn't exist.
+func (p *Porter) readLockFile(path string) (LockFile, error) {
+	var lockFile LockFile
+
+	if exists, _ := p.FileSystem.Exists(path); !exists {
+		return lockFile, nil
+	}
+
+	data, err := p.FileSystem.ReadFile(path)
+	if err != nil {
+		return lockFile, errors.Wrapf(err, "could not read %s", path)
+	}
+
+	err = yaml.Unmarshal(data, &lockFile)
+	return lockFile, errors.Wrapf(err, "could not unmarshal %s", path)
+}
+
+func (p *Porter) writeLockFile(path string, lockFile LockFile) error {
+	data, err := yaml.Marshal(lockFile)
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal %s", path)
+	}
+
+	err = p.FileSystem.WriteFile(path, data, 0644)
+	return errors.Wrapf(err, "could not write %s", path)
+}
@@ -0,0 +1,87 @@
+package porter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"get.porter.sh/porter/pkg/build"
+	"get.porter.sh/porter/pkg/cnab/extensions"
+	"get.porter.sh/porter/pkg/config"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPorter_lockDependencies(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+	p.TestConfig.TestContext.AddTestFile("../../build/testdata/bundles/wordpress/porter.yaml", config.Name)
+	p.CNAB = NewTestCNABProvider()
+	p.Cache = &mockCache{
+		findBundleMock: func(tag string) (string, string, bool, error) {
+			return "/cache/" + tag + "/bundle.json", "", true, nil
+		},
+	}
+
+	err := p.LoadManifest()
+	require.NoError(t, err)
+
+	depBun, _ := p.CNAB.LoadBundle("", true)
+	digest, err := extensions.DigestBundle(depBun)
+	require.NoError(t, err)
+	wantLocks := extensions.DependencyLocks{
+		{Alias: "mysql", Tag: "getporter/mysql:v0.1.1", Digest: digest},
+	}
+
+	locks, err := p.lockDependencies(BundlePullOptions{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, wantLocks, locks)
+
+	lockFile, err := p.readLockFile(config.LockName)
+	require.NoError(t, err)
+	assert.Equal(t, wantLocks, lockFile.Dependencies, "porter.lock was not written")
+
+	err = p.buildBundle("foo", "digest", locks)
+	require.NoError(t, err)
+
+	bunData, err := p.FileSystem.ReadFile(build.LOCAL_BUNDLE)
+	require.NoError(t, err)
+	var bun bundle.Bundle
+	err = json.Unmarshal(bunData, &bun)
+	require.NoError(t, err)
+
+	bunLocks, err := extensions.ReadDependencyLocks(&bun)
+	require.NoError(t, err)
+	assert.Equal(t, wantLocks, bunLocks, "the locks were not recorded in the bundle")
+}
+
+func TestPorter_lockDependencies_LockChanged(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+	p.TestConfig.TestContext.AddTestFile("../../build/testdata/bundles/wordpress/porter.yaml", config.Name)
+	p.CNAB = NewTestCNABProvider()
+	p.Cache = &mockCache{
+		findBundleMock: func(tag string) (string, string, bool, error) {
+			return "/cache/" + tag + "/bundle.json", "", true, nil
+		},
+	}
+
+	err := p.LoadManifest()
+	require.NoError(t, err)
+
+	staleLock := LockFile{
+		Dependencies: extensions.DependencyLocks{
+			{Alias: "mysql", Tag: "getporter/mysql:v0.1.1", Digest: "sha256:abc123"},
+		},
+	}
+	err = p.writeLockFile(config.LockName, staleLock)
+	require.NoError(t, err)
+
+	_, err = p.lockDependencies(BundlePullOptions{}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has changed since it was locked")
+
+	locks, err := p.lockDependencies(BundlePullOptions{}, true)
+	require.NoError(t, err, "updating the lock should ignore the previously locked versions")
+	assert.NotEqual(t, "sha256:abc123", locks[0].Digest)
+}
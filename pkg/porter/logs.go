@@ -0,0 +1,60 @@
+package porter
+
+import (
+	"fmt"
+
+	"get.porter.sh/porter/pkg/claims"
+	"get.porter.sh/porter/pkg/context"
+	"github.com/pkg/errors"
+)
+
+// LogsOptions represent options for showing the logs of a run of a bundle instance.
+type LogsOptions struct {
+	sharedOptions
+
+	// RunID is the id of the run, defaults to the last run of the bundle instance.
+	RunID string
+}
+
+// Validate prepares for the logs action and validates the args/options.
+func (o *LogsOptions) Validate(args []string, cxt *context.Context) error {
+	// Ensure only one argument exists (instance name) if args length non-zero
+	err := o.sharedOptions.validateInstanceName(args)
+	if err != nil {
+		return err
+	}
+
+	return o.sharedOptions.defaultBundleFiles(cxt)
+}
+
+// ShowInstanceLogs prints the logs of a run of a bundle instance, which defaults to
+// the last run.
+func (p *Porter) ShowInstanceLogs(opts LogsOptions) error {
+	err := p.applyDefaultOptions(&opts.sharedOptions)
+	if err != nil {
+		return err
+	}
+
+	runID := opts.RunID
+	if runID == "" {
+		runs, err := p.Claims.ReadHistory(opts.Name)
+		if err != nil {
+			return errors.Wrapf(err, "could not read the history of bundle instance %s", opts.Name)
+		}
+		if len(runs) == 0 {
+			return errors.Errorf("no runs were recorded for bundle instance %s", opts.Name)
+		}
+		runID = runs[len(runs)-1].ID
+	}
+
+	logs, err := p.Claims.ReadLogs(opts.Name, runID)
+	if err != nil {
+		if err == claims.ErrLogsNotFound {
+			return errors.Errorf("no logs were recorded for run %s of bundle instance %s", runID, opts.Name)
+		}
+		return err
+	}
+
+	fmt.Fprint(p.Out, logs)
+	return nil
+}
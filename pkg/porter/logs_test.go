@@ -0,0 +1,56 @@
+package porter
+
+import (
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/claims"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPorter_ShowInstanceLogs(t *testing.T) {
+	testcases := []struct {
+		name      string
+		runID     string
+		wantLogs  string
+		wantError string
+	}{
+		{name: "last run", wantLogs: "upgrading mybuns..."},
+		{name: "specific run", runID: "01E2ZZ0", wantLogs: "installing mybuns..."},
+		{name: "missing run", runID: "01E2ZZ9", wantError: "no logs were recorded for run 01E2ZZ9 of bundle instance mybuns"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewTestPorter(t)
+
+			now := time.Now()
+			require.NoError(t, p.Claims.SaveRun(claims.Run{ID: "01E2ZZ0", Installation: "mybuns", Action: "install", Timestamp: now}))
+			require.NoError(t, p.Claims.SaveLogs("mybuns", "01E2ZZ0", "installing mybuns..."))
+			require.NoError(t, p.Claims.SaveRun(claims.Run{ID: "01E2ZZ1", Installation: "mybuns", Action: "upgrade", Timestamp: now.Add(time.Minute)}))
+			require.NoError(t, p.Claims.SaveLogs("mybuns", "01E2ZZ1", "upgrading mybuns..."))
+
+			opts := LogsOptions{RunID: tc.runID}
+			opts.Name = "mybuns"
+
+			err := p.ShowInstanceLogs(opts)
+			if tc.wantError != "" {
+				require.EqualError(t, err, tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantLogs, p.TestConfig.TestContext.GetOutput())
+		})
+	}
+}
+
+func TestPorter_ShowInstanceLogs_NoRuns(t *testing.T) {
+	p := NewTestPorter(t)
+
+	opts := LogsOptions{}
+	opts.Name = "mybuns"
+
+	err := p.ShowInstanceLogs(opts)
+	require.EqualError(t, err, "no runs were recorded for bundle instance mybuns")
+}
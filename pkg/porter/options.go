@@ -1,5 +1,7 @@
 package porter
 
+import "github.com/pkg/errors"
+
 // applyDefaultOptions applies more advanced defaults to the options
 // based on values that beyond just what was supplied by the user
 // such as information in the manifest itself.
@@ -18,6 +20,14 @@ func (p *Porter) applyDefaultOptions(opts *sharedOptions) error {
 		opts.Name = p.Manifest.Name
 	}
 
+	//
+	// Resolve the parameters from the parameter sets
+	//
+	err := p.loadParameterSets(opts)
+	if err != nil {
+		return err
+	}
+
 	//
 	// Default the porter-debug param to --debug
 	//
@@ -30,3 +40,28 @@ func (p *Porter) applyDefaultOptions(opts *sharedOptions) error {
 
 	return nil
 }
+
+// loadParameterSets resolves the values of the parameters in the parameter sets, and
+// combines them with the parameters specified with --param and --param-file.
+func (p *Porter) loadParameterSets(opts *sharedOptions) error {
+	if len(opts.ParameterSets) == 0 {
+		return nil
+	}
+
+	opts.parsedParamSets = make([]map[string]string, 0, len(opts.ParameterSets))
+	for _, name := range opts.ParameterSets {
+		ps, err := p.Parameters.Read(name)
+		if err != nil {
+			return errors.Wrapf(err, "could not read parameter set %s", name)
+		}
+
+		params, err := p.Parameters.ResolveAll(ps)
+		if err != nil {
+			return err
+		}
+		opts.parsedParamSets = append(opts.parsedParamSets, params)
+	}
+
+	opts.combinedParameters = opts.combineParameters()
+	return nil
+}
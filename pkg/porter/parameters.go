@@ -0,0 +1,307 @@
+package porter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/parameters"
+	"get.porter.sh/porter/pkg/printer"
+
+	dtprinter "github.com/carolynvs/datetime-printer"
+	tablewriter "github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ParameterOptions represent options for Porter's parameters create command
+type ParameterOptions struct {
+	BundleLifecycleOpts
+	DryRun bool
+	Silent bool
+}
+
+// Validate prepares for an action and validates the options.
+// For example, relative paths are converted to full paths and then checked that
+// they exist and are accessible.
+func (o *ParameterOptions) Validate(args []string, cxt *context.Context) error {
+	if len(args) == 1 {
+		o.Name = args[0]
+	} else if len(args) > 1 {
+		return errors.Errorf("only one positional argument may be specified, the parameter set name, but multiple were received: %s", args)
+	}
+
+	return o.bundleFileOptions.Validate(cxt)
+}
+
+// ParameterShowOptions represent options for Porter's parameters show command
+type ParameterShowOptions struct {
+	printer.PrintOptions
+	Name string
+}
+
+// Validate validates the args provided Porter's parameters show command
+func (o *ParameterShowOptions) Validate(args []string) error {
+	name, err := validateParameterSetName(args)
+	if err != nil {
+		return err
+	}
+	o.Name = name
+
+	return o.ParseFormat()
+}
+
+// ParameterEditOptions represent options for Porter's parameters edit command
+type ParameterEditOptions struct {
+	Name string
+}
+
+// Validate validates the args provided Porter's parameters edit command
+func (o *ParameterEditOptions) Validate(args []string) error {
+	name, err := validateParameterSetName(args)
+	o.Name = name
+	return err
+}
+
+// ParameterDeleteOptions represent options for Porter's parameters delete command
+type ParameterDeleteOptions struct {
+	Name string
+}
+
+// Validate validates the args provided Porter's parameters delete command
+func (o *ParameterDeleteOptions) Validate(args []string) error {
+	name, err := validateParameterSetName(args)
+	o.Name = name
+	return err
+}
+
+// validateParameterSetName grabs the parameter set name from the first positional argument.
+func validateParameterSetName(args []string) (string, error) {
+	switch len(args) {
+	case 0:
+		return "", errors.Errorf("no parameter set name was specified")
+	case 1:
+		return strings.ToLower(args[0]), nil
+	default:
+		return "", errors.Errorf("only one positional argument may be specified, the parameter set name, but multiple were received: %s", args)
+	}
+}
+
+// ListParameters lists saved parameter sets.
+func (p *Porter) ListParameters(opts ListOptions) error {
+	params, err := p.Parameters.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		return printer.PrintJson(p.Out, params)
+	case printer.FormatYaml:
+		return printer.PrintYaml(p.Out, params)
+	case printer.FormatTable:
+		// have every row use the same "now" starting ... NOW!
+		now := time.Now()
+		tp := dtprinter.DateTimePrinter{
+			Now: func() time.Time { return now },
+		}
+
+		printParamRow :=
+			func(v interface{}) []interface{} {
+				ps, ok := v.(parameters.ParameterSet)
+				if !ok {
+					return nil
+				}
+				return []interface{}{ps.Name, tp.Format(ps.Modified)}
+			}
+		return printer.PrintTable(p.Out, params, printParamRow,
+			"NAME", "MODIFIED")
+	default:
+		return fmt.Errorf("invalid format: %s", opts.Format)
+	}
+}
+
+// CreateParameters builds a new parameter set for the parameters of a bundle. This can be either
+// a silent build, based on the opts.Silent flag, or interactive using a survey.
+func (p *Porter) CreateParameters(opts ParameterOptions) error {
+	err := p.prepullBundleByTag(&opts.BundleLifecycleOpts)
+	if err != nil {
+		return errors.Wrap(err, "unable to pull bundle before invoking parameters create")
+	}
+
+	err = p.applyDefaultOptions(&opts.sharedOptions)
+	if err != nil {
+		return err
+	}
+	err = p.ensureLocalBundleIsUpToDate(opts.bundleFileOptions)
+	if err != nil {
+		return err
+	}
+	bundle, err := p.CNAB.LoadBundle(opts.CNABFile, opts.Insecure)
+	if err != nil {
+		return err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = bundle.Name
+	}
+	genOpts := parameters.GenerateOptions{
+		Name:       name,
+		Parameters: bundle.Parameters,
+		Silent:     opts.Silent,
+	}
+	fmt.Fprintf(p.Out, "Generating new parameter set %s from bundle %s\n", genOpts.Name, bundle.Name)
+
+	ps, err := parameters.GenerateParameters(genOpts)
+	if err != nil {
+		return errors.Wrap(err, "unable to generate parameter set")
+	}
+	fmt.Fprintf(p.Out, "==> %d parameters defined for bundle %s\n", len(ps.Parameters), bundle.Name)
+
+	ps.Created = time.Now()
+	ps.Modified = ps.Created
+
+	if opts.DryRun {
+		data, err := json.Marshal(ps)
+		if err != nil {
+			return errors.Wrap(err, "unable to generate parameter set JSON")
+		}
+		fmt.Fprintf(p.Out, "%v", string(data))
+		return nil
+	}
+	err = p.Parameters.Save(*ps)
+	return errors.Wrapf(err, "unable to save parameter set")
+}
+
+// ShowParameter shows the parameter set corresponding to the provided name, using
+// the provided printer.PrintOptions for display.
+func (p *Porter) ShowParameter(opts ParameterShowOptions) error {
+	paramSet, err := p.Parameters.Read(opts.Name)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		return printer.PrintJson(p.Out, paramSet)
+	case printer.FormatYaml:
+		return printer.PrintYaml(p.Out, paramSet)
+	case printer.FormatTable:
+		// Set up human friendly time formatter
+		now := time.Now()
+		tp := dtprinter.DateTimePrinter{
+			Now: func() time.Time { return now },
+		}
+
+		table := tablewriter.NewWriter(p.Out)
+		table.SetCenterSeparator("")
+		table.SetColumnSeparator("")
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+		table.SetBorders(tablewriter.Border{Left: false, Right: false, Bottom: false, Top: true})
+		table.SetAutoFormatHeaders(false)
+
+		// First, print the ParameterSet metadata
+		fmt.Fprintf(p.Out, "Name: %s\n", paramSet.Name)
+		fmt.Fprintf(p.Out, "Created: %s\n", tp.Format(paramSet.Created))
+		fmt.Fprintf(p.Out, "Modified: %s\n\n", tp.Format(paramSet.Modified))
+
+		// Now print the table
+		table.SetHeader([]string{"Name", "Local Source", "Source Type"})
+		for _, ps := range paramSet.Parameters {
+			table.Append([]string{ps.Name, ps.Source.Value, ps.Source.Key})
+		}
+		table.Render()
+		return nil
+	default:
+		return fmt.Errorf("invalid format: %s", opts.Format)
+	}
+}
+
+// EditParameter opens the parameter set in the user's editor, and saves the changes
+// when the editor is closed.
+func (p *Porter) EditParameter(opts ParameterEditOptions) error {
+	paramSet, err := p.Parameters.Read(opts.Name)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(paramSet)
+	if err != nil {
+		return errors.Wrapf(err, "unable to marshal parameter set %s", opts.Name)
+	}
+
+	data, err = p.editContents(fmt.Sprintf("%s.yaml", opts.Name), data)
+	if err != nil {
+		return errors.Wrapf(err, "unable to edit parameter set %s", opts.Name)
+	}
+
+	var updated parameters.ParameterSet
+	err = yaml.Unmarshal(data, &updated)
+	if err != nil {
+		return errors.Wrapf(err, "unable to parse the edited parameter set %s", opts.Name)
+	}
+
+	// The name and creation time of the parameter set can't be changed
+	updated.Name = paramSet.Name
+	updated.Created = paramSet.Created
+	updated.Modified = time.Now()
+
+	err = p.Parameters.Save(updated)
+	return errors.Wrapf(err, "unable to save parameter set %s", opts.Name)
+}
+
+// DeleteParameter deletes the parameter set with the provided name.
+func (p *Porter) DeleteParameter(opts ParameterDeleteOptions) error {
+	// Read the parameter set first, so that we return a friendly error when it doesn't exist
+	_, err := p.Parameters.Read(opts.Name)
+	if err != nil {
+		return err
+	}
+
+	err = p.Parameters.Delete(opts.Name)
+	return errors.Wrapf(err, "unable to delete parameter set %s", opts.Name)
+}
+
+// editContents writes the contents to a temporary file with the specified name, opens it
+// in the editor defined by the EDITOR environment variable and returns the edited contents.
+func (p *Porter) editContents(name string, contents []byte) ([]byte, error) {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	tmpDir, err := p.FileSystem.TempDir("", "porter")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create temporary directory")
+	}
+	defer p.FileSystem.RemoveAll(tmpDir)
+
+	tmpFile := filepath.Join(tmpDir, name)
+	err = p.FileSystem.WriteFile(tmpFile, contents, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not write temporary file %s", tmpFile)
+	}
+
+	// The editor may include arguments, for example "code --wait"
+	editorArgs := strings.Fields(editor)
+	cmd := p.NewCommand(editorArgs[0], append(editorArgs[1:], tmpFile)...)
+	cmd.Stdin = p.In
+	cmd.Stdout = p.Out
+	cmd.Stderr = p.Err
+	err = cmd.Run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not run the editor %s", editor)
+	}
+
+	return p.FileSystem.ReadFile(tmpFile)
+}
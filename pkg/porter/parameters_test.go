@@ -0,0 +1,263 @@
+package porter
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/parameters"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/test"
+	"github.com/cnabio/cnab-go/credentials"
+	"github.com/cnabio/cnab-go/secrets/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateParameters(t *testing.T) {
+	p := NewTestPorter(t)
+	p.CNAB = &TestCNABProvider{}
+
+	opts := ParameterOptions{
+		Silent: true,
+	}
+	opts.Name = "dev"
+
+	err := p.CreateParameters(opts)
+	require.NoError(t, err)
+
+	ps, err := p.Parameters.Read("dev")
+	require.NoError(t, err, "expected the parameter set to have been created")
+	var zero time.Time
+	assert.True(t, zero.Before(ps.Created), "expected ParameterSet.Created to be set")
+	assert.True(t, ps.Created.Equal(ps.Modified), "expected ParameterSet.Created to be initialized to ParameterSet.Modified")
+	require.Len(t, ps.Parameters, 1, "the parameters defined by porter should not be included")
+	assert.Equal(t, "region", ps.Parameters[0].Name)
+}
+
+func TestCreateParameters_DefaultName(t *testing.T) {
+	p := NewTestPorter(t)
+	p.CNAB = &TestCNABProvider{}
+
+	opts := ParameterOptions{
+		Silent: true,
+	}
+
+	err := p.CreateParameters(opts)
+	require.NoError(t, err)
+
+	_, err = p.Parameters.Read("testbundle")
+	require.NoError(t, err, "expected the parameter set to be named after the bundle")
+}
+
+func TestCreateParameters_DryRun(t *testing.T) {
+	p := NewTestPorter(t)
+	p.CNAB = &TestCNABProvider{}
+
+	opts := ParameterOptions{
+		Silent: true,
+		DryRun: true,
+	}
+	opts.Name = "dev"
+
+	err := p.CreateParameters(opts)
+	require.NoError(t, err)
+
+	_, err = p.Parameters.Read("dev")
+	assert.Equal(t, parameters.ErrNotFound, err, "the parameter set should not be saved during a dry run")
+	assert.Contains(t, p.TestConfig.TestContext.GetOutput(), `"name":"dev"`)
+}
+
+func TestListParameters(t *testing.T) {
+	testcases := []struct {
+		name         string
+		format       printer.Format
+		wantContains string
+		wantError    string
+	}{
+		{name: "json", format: printer.FormatJson, wantContains: `"name": "dev"`},
+		{name: "yaml", format: printer.FormatYaml, wantContains: `- name: dev`},
+		{name: "table", format: printer.FormatTable, wantContains: "NAME   MODIFIED\ndev    2019-06-24"},
+		{name: "invalid format", format: "wingdings", wantError: "invalid format: wingdings"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewTestPorter(t)
+			p.TestParameters.AddTestParameters("testdata/test-params/dev.json")
+
+			opts := ListOptions{}
+			opts.Format = tc.format
+			err := p.ListParameters(opts)
+			if tc.wantError != "" {
+				require.EqualError(t, err, tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, p.TestConfig.TestContext.GetOutput(), tc.wantContains)
+		})
+	}
+}
+
+func TestShowParameter(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestParameters.AddTestParameters("testdata/test-params/dev.json")
+
+	opts := ParameterShowOptions{
+		PrintOptions: printer.PrintOptions{
+			Format: printer.FormatTable,
+		},
+		Name: "dev",
+	}
+
+	err := p.ShowParameter(opts)
+	require.NoError(t, err)
+
+	wantOutput := `Name: dev
+Created: 2019-06-24
+Modified: 2019-06-24
+
+---------------------------------------
+  Name      Local Source  Source Type  
+---------------------------------------
+  region    eastus        value        
+  replicas  1             value        
+  password  DEV_PASSWORD  env          
+`
+	assert.Equal(t, wantOutput, p.TestConfig.TestContext.GetOutput())
+}
+
+func TestShowParameter_NotFound(t *testing.T) {
+	p := NewTestPorter(t)
+
+	opts := ParameterShowOptions{
+		PrintOptions: printer.PrintOptions{
+			Format: printer.FormatTable,
+		},
+		Name: "missing",
+	}
+
+	err := p.ShowParameter(opts)
+	assert.EqualError(t, err, "Parameter set does not exist")
+}
+
+func TestParameterShowOptions_Validate(t *testing.T) {
+	opts := ParameterShowOptions{}
+	opts.RawFormat = "json"
+
+	err := opts.Validate(nil)
+	assert.EqualError(t, err, "no parameter set name was specified")
+
+	err = opts.Validate([]string{"dev", "prod"})
+	assert.EqualError(t, err, "only one positional argument may be specified, the parameter set name, but multiple were received: [dev prod]")
+
+	err = opts.Validate([]string{"Dev"})
+	require.NoError(t, err)
+	assert.Equal(t, "dev", opts.Name)
+}
+
+func TestEditParameter(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestParameters.AddTestParameters("testdata/test-params/dev.json")
+
+	os.Setenv("EDITOR", "vi")
+	defer os.Unsetenv("EDITOR")
+	os.Setenv(test.ExpectedCommandEnv, "vi")
+	defer os.Unsetenv(test.ExpectedCommandEnv)
+
+	// Simulate the user editing the file, the mocked editor doesn't change it
+	var gotFile string
+	p.NewCommand = func(command string, args ...string) *exec.Cmd {
+		require.Len(t, args, 1, "expected the editor to be passed the file to edit")
+		gotFile = args[0]
+		edited := `name: renamed
+parameters:
+- name: region
+  source:
+    value: westus
+`
+		require.NoError(t, p.FileSystem.WriteFile(gotFile, []byte(edited), 0600))
+		return context.NewTestCommand()(command)
+	}
+
+	err := p.EditParameter(ParameterEditOptions{Name: "dev"})
+	require.NoError(t, err)
+	assert.Equal(t, "dev.yaml", filepath.Base(gotFile))
+
+	ps, err := p.Parameters.Read("dev")
+	require.NoError(t, err)
+	assert.Equal(t, "dev", ps.Name, "the parameter set should not be renamed")
+	assert.Equal(t, []parameters.ParameterStrategy{
+		{Name: "region", Source: credentials.Source{Key: host.SourceValue, Value: "westus"}},
+	}, ps.Parameters)
+	assert.True(t, ps.Modified.After(ps.Created), "expected ParameterSet.Modified to be updated")
+
+	_, err = p.Parameters.Read("renamed")
+	assert.Equal(t, parameters.ErrNotFound, err)
+}
+
+func TestDeleteParameter(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestParameters.AddTestParameters("testdata/test-params/dev.json")
+
+	err := p.DeleteParameter(ParameterDeleteOptions{Name: "dev"})
+	require.NoError(t, err)
+
+	_, err = p.Parameters.Read("dev")
+	assert.Equal(t, parameters.ErrNotFound, err, "the parameter set should have been deleted")
+
+	err = p.DeleteParameter(ParameterDeleteOptions{Name: "dev"})
+	assert.EqualError(t, err, "Parameter set does not exist")
+}
+
+func TestApplyDefaultOptions_ParameterSets(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestParameters.SecretsStore = secrets.NewSecretStore(&host.SecretStore{})
+	p.TestParameters.AddTestParameters("testdata/test-params/dev.json")
+	err := p.Parameters.Save(parameters.ParameterSet{
+		Name: "westus",
+		Parameters: []parameters.ParameterStrategy{
+			{Name: "region", Source: credentials.Source{Key: host.SourceValue, Value: "westus"}},
+		},
+	})
+	require.NoError(t, err)
+
+	os.Setenv("DEV_PASSWORD", "s3cr3t")
+	defer os.Unsetenv("DEV_PASSWORD")
+
+	p.Debug = false
+	opts := sharedOptions{
+		Name:          "mybuns",
+		ParameterSets: []string{"dev", "westus"},
+		parsedParamFiles: []map[string]string{
+			{"replicas": "2"},
+		},
+		parsedParams: map[string]string{},
+	}
+
+	err = p.applyDefaultOptions(&opts)
+	require.NoError(t, err)
+
+	wantParams := map[string]string{
+		"region":   "westus",
+		"replicas": "2",
+		"password": "s3cr3t",
+	}
+	assert.Equal(t, wantParams, opts.combinedParameters, "the last parameter set should win, and parameter files should take precedence over parameter sets")
+}
+
+func TestApplyDefaultOptions_ParameterSetNotFound(t *testing.T) {
+	p := NewTestPorter(t)
+
+	opts := sharedOptions{
+		Name:          "mybuns",
+		ParameterSets: []string{"missing"},
+	}
+
+	err := p.applyDefaultOptions(&opts)
+	assert.EqualError(t, err, "could not read parameter set missing: Parameter set does not exist")
+}
@@ -13,6 +13,7 @@ import (
 	"get.porter.sh/porter/pkg/manifest"
 	"get.porter.sh/porter/pkg/mixin"
 	mixinprovider "get.porter.sh/porter/pkg/mixin/provider"
+	"get.porter.sh/porter/pkg/parameters"
 	"get.porter.sh/porter/pkg/plugins"
 	"get.porter.sh/porter/pkg/storage/pluginstore"
 	"get.porter.sh/porter/pkg/templates"
@@ -24,6 +25,7 @@ type Porter struct {
 
 	Cache       cache.BundleCache
 	Credentials credentials.CredentialProvider
+	Parameters  parameters.ParameterProvider
 	Claims      claims.ClaimProvider
 	Registry    Registry
 	Templates   *templates.Templates
@@ -32,6 +34,9 @@ type Porter struct {
 	Mixins      mixin.MixinProvider
 	Plugins     plugins.PluginProvider
 	CNAB        CNABProvider
+
+	// askParameter prompts the user for the value of a parameter, defaults to a survey.
+	askParameter parameters.AskFunc
 }
 
 // New porter client, initialized with useful defaults.
@@ -41,11 +46,13 @@ func New() *Porter {
 	storagePlugin := pluginstore.NewStore(c)
 	claimStorage := claims.NewClaimStorage(c, storagePlugin)
 	credStorage := credentials.NewCredentialStorage(c, storagePlugin)
+	paramStorage := parameters.NewParameterStorage(c, storagePlugin)
 	return &Porter{
 		Config:      c,
 		Cache:       cache,
 		Claims:      claimStorage,
 		Credentials: credStorage,
+		Parameters:  paramStorage,
 		Registry:    cnabtooci.NewRegistry(c.Context),
 		Templates:   templates.NewTemplates(),
 		Builder:     buildprovider.NewDockerBuilder(c.Context),
@@ -316,7 +316,12 @@ func (p *Porter) rewriteBundleWithInvocationImageDigest(digest string) (*bundle.
 	}
 
 	fmt.Fprintln(p.Out, "\nRewriting CNAB bundle.json...")
-	err = p.buildBundle(taggedImage, digest)
+	locks, err := p.lockDependencies(BundlePullOptions{}, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to lock the bundle's dependencies")
+	}
+
+	err = p.buildBundle(taggedImage, digest, locks)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to rewrite CNAB bundle.json with updated invocation image digest")
 	}
@@ -0,0 +1,76 @@
+package porter
+
+import (
+	"fmt"
+
+	"get.porter.sh/porter/pkg/claims"
+	"get.porter.sh/porter/pkg/manifest"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/pkg/errors"
+)
+
+// resumeState describes the last run of an action against an installation.
+type resumeState int
+
+const (
+	// resumeNone indicates that there isn't a run of the action to resume.
+	resumeNone resumeState = iota
+
+	// resumeCompleted indicates that the last run of the action completed.
+	resumeCompleted
+
+	// resumeFailed indicates that the last run of the action failed, and
+	// recorded which steps completed so that it can be resumed.
+	resumeFailed
+)
+
+// getResumeState determines if the last run of an action against an installation can be resumed.
+func getResumeState(store claims.ClaimProvider, installation string, action manifest.Action) (resumeState, error) {
+	c, err := store.Read(installation)
+	if err != nil {
+		if err == claim.ErrClaimNotFound {
+			return resumeNone, nil
+		}
+		return resumeNone, errors.Wrapf(err, "could not read the installation %s", installation)
+	}
+
+	if c.Result.Action != string(action) {
+		return resumeNone, nil
+	}
+
+	switch c.Result.Status {
+	case claim.StatusSuccess:
+		return resumeCompleted, nil
+	case claim.StatusFailure:
+		progress, err := claims.ReadActionProgress(c)
+		if err != nil {
+			return resumeNone, err
+		}
+		if progress == nil {
+			return resumeNone, nil
+		}
+		return resumeFailed, nil
+	default:
+		return resumeNone, nil
+	}
+}
+
+// resumeInstallation determines if the action against the installation should
+// skip the steps that completed in its last failed run.
+func (p *Porter) resumeInstallation(installation string, action manifest.Action) (bool, error) {
+	state, err := getResumeState(p.Claims, installation, action)
+	if err != nil {
+		return false, err
+	}
+
+	switch state {
+	case resumeFailed:
+		fmt.Fprintf(p.Out, "Resuming the failed %s of %s...\n", action, installation)
+		return true, nil
+	case resumeCompleted:
+		fmt.Fprintf(p.Out, "The last %s of %s completed, there is nothing to resume. Executing every step...\n", action, installation)
+	default:
+		fmt.Fprintf(p.Out, "There is no failed %s of %s to resume. Executing every step...\n", action, installation)
+	}
+	return false, nil
+}
@@ -0,0 +1,63 @@
+package porter
+
+import (
+	"testing"
+
+	"get.porter.sh/porter/pkg/claims"
+	"get.porter.sh/porter/pkg/manifest"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetResumeState(t *testing.T) {
+	progress := map[string]interface{}{claims.StepsKey: claims.ActionProgress{Action: "install"}}
+
+	testcases := []struct {
+		name   string
+		claim  *claim.Claim
+		action manifest.Action
+		want   resumeState
+	}{
+		{"not installed", nil, manifest.ActionInstall, resumeNone},
+		{"failed", &claim.Claim{Result: claim.Result{Action: "install", Status: claim.StatusFailure}, Custom: progress}, manifest.ActionInstall, resumeFailed},
+		{"failed without progress", &claim.Claim{Result: claim.Result{Action: "install", Status: claim.StatusFailure}}, manifest.ActionInstall, resumeNone},
+		{"completed", &claim.Claim{Result: claim.Result{Action: "install", Status: claim.StatusSuccess}}, manifest.ActionInstall, resumeCompleted},
+		{"different action", &claim.Claim{Result: claim.Result{Action: "install", Status: claim.StatusFailure}, Custom: progress}, manifest.ActionUpgrade, resumeNone},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := claims.NewTestClaimProvider()
+			if tc.claim != nil {
+				tc.claim.Name = "mysql"
+				require.NoError(t, store.Save(*tc.claim))
+			}
+
+			got, err := getResumeState(store, "mysql", tc.action)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPorter_ResumeInstallation(t *testing.T) {
+	p := NewTestPorter(t)
+
+	resume, err := p.resumeInstallation("mysql", manifest.ActionInstall)
+	require.NoError(t, err)
+	assert.False(t, resume)
+	assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "There is no failed install of mysql to resume. Executing every step...")
+
+	err = p.Claims.Save(claim.Claim{
+		Name:   "mysql",
+		Result: claim.Result{Action: "install", Status: claim.StatusFailure},
+		Custom: map[string]interface{}{claims.StepsKey: claims.ActionProgress{Action: "install"}},
+	})
+	require.NoError(t, err)
+
+	resume, err = p.resumeInstallation("mysql", manifest.ActionInstall)
+	require.NoError(t, err)
+	assert.True(t, resume)
+	assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "Resuming the failed install of mysql...")
+}
@@ -0,0 +1,164 @@
+package porter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"get.porter.sh/porter/pkg/claims"
+	cnabprovider "get.porter.sh/porter/pkg/cnab/provider"
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/parameters"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/pkg/errors"
+)
+
+// RollbackOptions that may be specified when rolling back a bundle instance.
+type RollbackOptions struct {
+	sharedOptions
+
+	// To is the id of the run to roll back to. Defaults to the last successful
+	// install or upgrade before the last run.
+	To string
+}
+
+// Validate prepares for the rollback action and validates the args/options.
+func (o *RollbackOptions) Validate(args []string, cxt *context.Context) error {
+	err := o.sharedOptions.validateInstanceName(args)
+	if err != nil {
+		return err
+	}
+
+	err = o.sharedOptions.defaultBundleFiles(cxt)
+	if err != nil {
+		return err
+	}
+
+	o.defaultDriver()
+	return o.validateDriver()
+}
+
+// RollbackInstance upgrades a bundle instance with the bundle and parameters of a previous
+// successful run, returning the instance to the state of that run. The credentials are
+// resolved again, and the rollback is recorded in the history of the instance.
+func (p *Porter) RollbackInstance(opts RollbackOptions) error {
+	err := p.applyDefaultOptions(&opts.sharedOptions)
+	if err != nil {
+		return err
+	}
+
+	runs, err := p.Claims.ReadHistory(opts.Name)
+	if err != nil {
+		return errors.Wrapf(err, "could not read the history of bundle instance %s", opts.Name)
+	}
+
+	run, err := selectRollbackRun(opts.Name, runs, opts.To)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := p.Claims.ReadSnapshot(opts.Name, run.ID)
+	if err != nil {
+		if err == claims.ErrSnapshotNotFound {
+			return errors.Errorf("the bundle and parameters of run %s of bundle instance %s were not recorded, it cannot be rolled back to", run.ID, opts.Name)
+		}
+		return err
+	}
+	if snapshot.Bundle == nil {
+		return errors.Errorf("the bundle of run %s of bundle instance %s was not recorded, it cannot be rolled back to", run.ID, opts.Name)
+	}
+
+	tmpDir, err := p.FileSystem.TempDir("", "porter")
+	if err != nil {
+		return errors.Wrap(err, "could not create temporary directory")
+	}
+	defer p.FileSystem.RemoveAll(tmpDir)
+
+	bundlePath := filepath.Join(tmpDir, "bundle.json")
+	bundleData, err := json.Marshal(snapshot.Bundle)
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal the bundle of run %s", run.ID)
+	}
+	err = p.FileSystem.WriteFile(bundlePath, bundleData, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "could not write the bundle of run %s to %s", run.ID, bundlePath)
+	}
+
+	params, err := p.rollbackParameters(snapshot, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	args := cnabprovider.ActionArguments{
+		Claim:                 opts.Name,
+		BundlePath:            bundlePath,
+		Insecure:              true,
+		Params:                params,
+		CredentialIdentifiers: opts.CredentialIdentifiers,
+		Driver:                opts.Driver,
+		RollbackTo:            run.ID,
+	}
+
+	fmt.Fprintf(p.Out, "rolling back %s to run %s of bundle %s %s...\n", opts.Name, run.ID, snapshot.Bundle.Name, snapshot.Bundle.Version)
+	return p.CNAB.Upgrade(args)
+}
+
+// selectRollbackRun returns the run to roll back to: the run with the specified id, or the
+// last successful install or upgrade before the last run of the installation.
+func selectRollbackRun(installation string, runs []claims.Run, id string) (claims.Run, error) {
+	canRollbackTo := func(run claims.Run) bool {
+		return run.Status == claim.StatusSuccess &&
+			(run.Action == claim.ActionInstall || run.Action == claim.ActionUpgrade)
+	}
+
+	if id != "" {
+		for _, run := range runs {
+			if run.ID != id {
+				continue
+			}
+			if !canRollbackTo(run) {
+				return claims.Run{}, errors.Errorf("cannot roll back to run %s of bundle instance %s, only successful install and upgrade runs can be rolled back to", id, installation)
+			}
+			return run, nil
+		}
+		return claims.Run{}, errors.Errorf("run %s was not found in the history of bundle instance %s", id, installation)
+	}
+
+	// Skip the last run, which is the state of the installation that is rolled back
+	for i := len(runs) - 2; i >= 0; i-- {
+		if canRollbackTo(runs[i]) {
+			return runs[i], nil
+		}
+	}
+	return claims.Run{}, errors.Errorf("bundle instance %s does not have a previous successful install or upgrade to roll back to", installation)
+}
+
+// rollbackParameters formats the parameters recorded for a run in the format used to
+// specify parameters. The contents of file parameters are written to files in dir,
+// because file parameters are specified with the path to the file.
+func (p *Porter) rollbackParameters(snapshot claims.Snapshot, dir string) (map[string]string, error) {
+	params := make(map[string]string, len(snapshot.Parameters))
+	for name, value := range snapshot.Parameters {
+		if param, ok := snapshot.Bundle.Parameters[name]; ok {
+			def, ok := snapshot.Bundle.Definitions[param.Definition]
+			if ok && def.Type == "string" && def.ContentEncoding == "base64" {
+				contents, err := base64.StdEncoding.DecodeString(fmt.Sprintf("%v", value))
+				if err != nil {
+					return nil, errors.Wrapf(err, "could not decode the recorded value of file parameter %s", name)
+				}
+
+				path := filepath.Join(dir, name)
+				err = p.FileSystem.WriteFile(path, contents, 0600)
+				if err != nil {
+					return nil, errors.Wrapf(err, "could not write the recorded value of file parameter %s to %s", name, path)
+				}
+				params[name] = path
+				continue
+			}
+		}
+
+		params[name] = parameters.FormatValue(value)
+	}
+	return params, nil
+}
@@ -0,0 +1,138 @@
+package porter
+
+import (
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/claims"
+	cnabprovider "get.porter.sh/porter/pkg/cnab/provider"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rollbackCNABProvider records the arguments of the upgrade, and the files that they
+// reference before the files are cleaned up.
+type rollbackCNABProvider struct {
+	*TestCNABProvider
+	p           *TestPorter
+	upgradeArgs *cnabprovider.ActionArguments
+	bundleData  []byte
+	fileParam   []byte
+}
+
+func (c *rollbackCNABProvider) Upgrade(args cnabprovider.ActionArguments) error {
+	c.upgradeArgs = &args
+
+	var err error
+	c.bundleData, err = c.p.FileSystem.ReadFile(args.BundlePath)
+	require.NoError(c.p.T(), err)
+	c.fileParam, err = c.p.FileSystem.ReadFile(args.Params["kubeconfig"])
+	require.NoError(c.p.T(), err)
+	return nil
+}
+
+func TestSelectRollbackRun(t *testing.T) {
+	runs := []claims.Run{
+		{ID: "01", Action: "install", Status: "success"},
+		{ID: "02", Action: "upgrade", Status: "failure"},
+		{ID: "03", Action: "upgrade", Status: "success"},
+		{ID: "04", Action: "logs", Status: "success"},
+		{ID: "05", Action: "upgrade", Status: "success"},
+	}
+
+	testcases := []struct {
+		name      string
+		runs      []claims.Run
+		to        string
+		wantRun   string
+		wantError string
+	}{
+		{name: "default", runs: runs, wantRun: "03"},
+		{name: "specified", runs: runs, to: "01", wantRun: "01"},
+		{name: "failed run", runs: runs, to: "02", wantError: "cannot roll back to run 02 of bundle instance mybuns, only successful install and upgrade runs can be rolled back to"},
+		{name: "custom action", runs: runs, to: "04", wantError: "cannot roll back to run 04 of bundle instance mybuns, only successful install and upgrade runs can be rolled back to"},
+		{name: "missing run", runs: runs, to: "99", wantError: "run 99 was not found in the history of bundle instance mybuns"},
+		{name: "only one run", runs: runs[:1], wantError: "bundle instance mybuns does not have a previous successful install or upgrade to roll back to"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			run, err := selectRollbackRun("mybuns", tc.runs, tc.to)
+			if tc.wantError != "" {
+				require.EqualError(t, err, tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRun, run.ID)
+		})
+	}
+}
+
+func TestPorter_RollbackInstance(t *testing.T) {
+	p := NewTestPorter(t)
+	cnab := &rollbackCNABProvider{TestCNABProvider: NewTestCNABProvider(), p: p}
+	p.CNAB = cnab
+	p.Debug = false
+
+	bun := &bundle.Bundle{
+		Name:    "mybuns",
+		Version: "0.1.0",
+		Definitions: definition.Definitions{
+			"file":    &definition.Schema{Type: "string", ContentEncoding: "base64"},
+			"string":  &definition.Schema{Type: "string"},
+			"integer": &definition.Schema{Type: "integer"},
+		},
+		Parameters: map[string]bundle.Parameter{
+			"kubeconfig": {Definition: "file"},
+			"region":     {Definition: "string"},
+			"replicas":   {Definition: "integer"},
+		},
+	}
+	now := time.Now()
+	require.NoError(t, p.Claims.SaveRun(claims.Run{ID: "01", Installation: "mybuns", Action: "install", Status: "success", Timestamp: now}))
+	require.NoError(t, p.Claims.SaveSnapshot("mybuns", "01", claims.Snapshot{
+		Bundle: bun,
+		Parameters: map[string]interface{}{
+			"kubeconfig": "SGVsbG8gV29ybGQh",
+			"region":     "eastus",
+			"replicas":   3,
+		},
+	}))
+	require.NoError(t, p.Claims.SaveRun(claims.Run{ID: "02", Installation: "mybuns", Action: "upgrade", Status: "success", Timestamp: now.Add(time.Minute)}))
+
+	opts := RollbackOptions{}
+	opts.Name = "mybuns"
+	opts.CredentialIdentifiers = []string{"azure"}
+	opts.Driver = "debug"
+
+	err := p.RollbackInstance(opts)
+	require.NoError(t, err)
+
+	args := cnab.upgradeArgs
+	require.NotNil(t, args, "expected the installation to be upgraded")
+	assert.Equal(t, "mybuns", args.Claim)
+	assert.Equal(t, "01", args.RollbackTo)
+	assert.Equal(t, []string{"azure"}, args.CredentialIdentifiers)
+	assert.Equal(t, "debug", args.Driver)
+	assert.Contains(t, string(cnab.bundleData), `"version":"0.1.0"`, "expected the bundle of the run to be used")
+	assert.Equal(t, "eastus", args.Params["region"])
+	assert.Equal(t, "3", args.Params["replicas"])
+	assert.Equal(t, "Hello World!", string(cnab.fileParam), "expected the contents of file parameters to be written to a file")
+	assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "rolling back mybuns to run 01 of bundle mybuns 0.1.0")
+}
+
+func TestPorter_RollbackInstance_NoSnapshot(t *testing.T) {
+	p := NewTestPorter(t)
+
+	now := time.Now()
+	require.NoError(t, p.Claims.SaveRun(claims.Run{ID: "01", Installation: "mybuns", Action: "install", Status: "success", Timestamp: now}))
+	require.NoError(t, p.Claims.SaveRun(claims.Run{ID: "02", Installation: "mybuns", Action: "upgrade", Status: "success", Timestamp: now.Add(time.Minute)}))
+
+	opts := RollbackOptions{}
+	opts.Name = "mybuns"
+
+	err := p.RollbackInstance(opts)
+	require.EqualError(t, err, "the bundle and parameters of run 01 of bundle instance mybuns were not recorded, it cannot be rolled back to")
+}
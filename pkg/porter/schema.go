@@ -6,8 +6,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/PaesslerAG/jsonpath"
-
 	"github.com/pkg/errors"
 )
 
@@ -38,17 +36,11 @@ func (p *Porter) GetManifestSchema() (jsonSchema, error) {
 		return replacementSchema, nil
 	}
 
-	b, err := p.Templates.GetSchema()
+	manifestSchema, err := p.getRootManifestSchema()
 	if err != nil {
 		return nil, err
 	}
 
-	manifestSchema := make(jsonSchema)
-	err = json.Unmarshal(b, &manifestSchema)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not unmarshal the root porter manifest schema")
-	}
-
 	combinedSchema, err := p.injectMixinSchemas(manifestSchema)
 	if err != nil {
 		if p.Debug {
@@ -61,42 +53,23 @@ func (p *Porter) GetManifestSchema() (jsonSchema, error) {
 	return combinedSchema, nil
 }
 
-func (p *Porter) injectMixinSchemas(manifestSchema jsonSchema) (jsonSchema, error) {
-	propertiesSchema, ok := manifestSchema["properties"].(jsonSchema)
-	if !ok {
-		return nil, errors.Errorf("root porter manifest schema has invalid properties type, expected map[string]interface{} but got %T", manifestSchema["properties"])
-	}
-
-	additionalPropertiesSchema, ok := manifestSchema["additionalProperties"].(jsonSchema)
-	if !ok {
-		return nil, errors.Errorf("root porter manifest schema has invalid additionalProperties type, expected map[string]interface{} but got %T", manifestSchema["additionalProperties"])
-	}
-
-	mixinSchema, ok := propertiesSchema["mixins"].(jsonSchema)
-	if !ok {
-		return nil, errors.Errorf("root porter manifest schema has invalid properties.mixins type, expected map[string]interface{} but got %T", propertiesSchema["mixins"])
-	}
-
-	mixinItemSchema, ok := mixinSchema["items"].(jsonSchema)
-	if !ok {
-		return nil, errors.Errorf("root porter manifest schema has invalid properties.mixins.items type, expected map[string]interface{} but got %T", mixinSchema["items"])
+func (p *Porter) getRootManifestSchema() (jsonSchema, error) {
+	b, err := p.Templates.GetSchema()
+	if err != nil {
+		return nil, err
 	}
 
-	mixinEnumSchema, ok := mixinItemSchema["enum"].([]interface{})
-	if !ok {
-		return nil, errors.Errorf("root porter manifest schema has invalid properties.mixins.items.enum type, expected []interface{} but got %T", mixinItemSchema["enum"])
+	manifestSchema := make(jsonSchema)
+	err = json.Unmarshal(b, &manifestSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal the root porter manifest schema")
 	}
 
-	coreActions := []string{"install", "upgrade", "uninstall"} // custom actions are defined in json schema as additionalProperties
-	actionSchemas := make(map[string]jsonSchema, len(coreActions)+1)
-	for _, action := range coreActions {
-		actionSchema, ok := propertiesSchema[action].(jsonSchema)
-		if !ok {
-			return nil, errors.Errorf("root porter manifest schema has invalid properties.%s type, expected map[string]interface{} but got %T", action, propertiesSchema[string(action)])
-		}
-		actionSchemas[action] = actionSchema
-	}
+	return manifestSchema, nil
+}
 
+// injectMixinSchemas embeds the schema of each installed mixin in the manifest schema.
+func (p *Porter) injectMixinSchemas(manifestSchema jsonSchema) (jsonSchema, error) {
 	mixins, err := p.Mixins.List()
 	if err != nil {
 		return nil, err
@@ -118,62 +91,139 @@ func (p *Porter) injectMixinSchemas(manifestSchema jsonSchema) (jsonSchema, erro
 
 		mixinSchemaMap := make(jsonSchema)
 		err = json.Unmarshal([]byte(mixinSchema), &mixinSchemaMap)
-		if err != nil && p.Debug {
-			fmt.Fprintln(p.Err, errors.Wrapf(err, "could not unmarshal mixin schema for %s, %q", mixin.Name, mixinSchema))
+		if err != nil {
+			if p.Debug {
+				fmt.Fprintln(p.Err, errors.Wrapf(err, "could not unmarshal mixin schema for %s, %q", mixin.Name, mixinSchema))
+			}
 			continue
 		}
 
-		mixinEnumSchema = append(mixinEnumSchema, mixin.Name)
+		err = injectMixinSchema(manifestSchema, mixin.Name, mixinSchemaMap)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-		// embed the entire mixin schema in the root
-		manifestSchema["mixin."+mixin.Name] = mixinSchemaMap
+	return manifestSchema, nil
+}
 
-		for _, action := range coreActions {
-			actionItemSchema, ok := actionSchemas[action]["items"].(jsonSchema)
-			if err != nil && p.Debug {
-				fmt.Fprintln(p.Err, errors.Errorf("root porter manifest schema has invalid properties.%s.items type, expected map[string]interface{} but got %T", action, actionSchemas[string(action)]["items"]))
-				continue
-			}
+// mixinStepDefinitions are the definitions in a mixin schema for the steps of each action.
+var mixinStepDefinitions = []string{"installStep", "upgradeStep", "uninstallStep", "invokeStep"}
 
-			actionAnyOfSchema, ok := actionItemSchema["anyOf"].([]interface{})
-			if !ok {
-				if err != nil && p.Debug {
-					fmt.Fprintln(p.Err, errors.Errorf("root porter manifest schema has invalid properties.%s.items.anyOf type, expected []interface{} but got %T", action, actionItemSchema["anyOf"]))
-					continue
-				}
-			}
+// injectMixinSchema embeds the schema of a mixin in the manifest schema, and allows the
+// mixin to be declared and its steps to be used in each action.
+func injectMixinSchema(manifestSchema jsonSchema, mixinName string, mixinSchema jsonSchema) error {
+	mixinItemSchemas, err := getSchemaArray(manifestSchema, "properties", "mixins", "items", "anyOf")
+	if err != nil {
+		return err
+	}
 
-			actionRef := fmt.Sprintf("#/mixin.%s/definitions/%sStep", mixin.Name, action)
-			actionAnyOfSchema = append(actionAnyOfSchema, jsonObject{"$ref": actionRef})
-			actionItemSchema["anyOf"] = actionAnyOfSchema
+	// Allow the mixin to be declared by name, or with its configuration
+	for _, itemSchema := range mixinItemSchemas {
+		itemSchema, ok := itemSchema.(jsonSchema)
+		if !ok {
+			continue
+		}
+		if _, ok := itemSchema["enum"]; ok {
+			err = appendSchemaArray(itemSchema, mixinName, "enum")
+		} else {
+			err = appendSchemaArray(itemSchema, mixinName, "propertyNames", "enum")
 		}
+		if err != nil {
+			return err
+		}
+	}
 
-		// Some mixins don't support custom actions, if the mixin has invokeStep defined,
-		// then use it in our additionalProperties list of acceptable root level elements.
-		_, err = jsonpath.Get("$.definitions.invokeStep", mixinSchemaMap)
-		if err == nil {
-			actionItemSchema, ok := additionalPropertiesSchema["items"].(jsonSchema)
-			if err != nil && p.Debug {
-				fmt.Fprintln(p.Err, errors.Errorf("root porter manifest schema has invalid additionalProperties.items type, expected map[string]interface{} but got %T", additionalPropertiesSchema["items"]))
-				continue
-			}
+	// embed the entire mixin schema in the root
+	manifestSchema["mixin."+mixinName] = mixinSchema
 
-			actionAnyOfSchema, ok := actionItemSchema["anyOf"].([]interface{})
-			if !ok && p.Debug {
-				fmt.Fprintln(p.Err, errors.Errorf("root porter manifest schema has invalid additionalProperties.items.anyOf type, expected []interface{} but got %T", actionItemSchema["anyOf"]))
-				continue
+	stepProperties, err := getSchemaObject(manifestSchema, "definitions", "step", "properties")
+	if err != nil {
+		return err
+	}
+
+	var stepRefs []interface{}
+	for _, definition := range mixinStepDefinitions {
+		stepSchema, err := getSchemaObject(mixinSchema, "definitions", definition)
+		if err != nil {
+			// Some mixins don't support custom actions and don't define invokeStep
+			continue
+		}
+
+		// Allow the fields that porter supports on every step
+		if properties, ok := stepSchema["properties"].(jsonSchema); ok {
+			for property := range stepProperties {
+				if _, ok := properties[property]; !ok {
+					properties[property] = jsonObject{"$ref": "#/definitions/step/properties/" + property}
+				}
 			}
+		}
+
+		stepRef := jsonObject{"$ref": fmt.Sprintf("#/mixin.%s/definitions/%s", mixinName, definition)}
+		stepRefs = append(stepRefs, stepRef)
+
+		switch definition {
+		case "invokeStep":
+			// If the mixin has invokeStep defined, then use it in our additionalProperties
+			// list of acceptable root level elements, which are custom actions
+			err = appendSchemaArray(manifestSchema, stepRef, "additionalProperties", "items", "anyOf")
+		default:
+			action := strings.TrimSuffix(definition, "Step")
+			err = appendSchemaArray(manifestSchema, stepRef, "properties", action, "items", "anyOf")
+		}
+		if err != nil {
+			return err
+		}
+	}
 
-			actionRef := fmt.Sprintf("#/mixin.%s/definitions/invokeStep", mixin.Name)
-			actionAnyOfSchema = append(actionAnyOfSchema, jsonObject{"$ref": actionRef})
-			actionItemSchema["anyOf"] = actionAnyOfSchema
+	// A parallel step group may be used in any action, so allow the steps for any action in the group
+	return appendSchemaArray(manifestSchema, stepRefs, "definitions", "parallelStep", "properties", "parallel", "items", "anyOf")
+}
+
+// getSchemaObject returns the object at the specified path in a schema.
+func getSchemaObject(schema jsonSchema, path ...string) (jsonSchema, error) {
+	current := schema
+	for i, key := range path {
+		next, ok := current[key].(jsonSchema)
+		if !ok {
+			return nil, errors.Errorf("root porter manifest schema has invalid %s type, expected map[string]interface{} but got %T", strings.Join(path[:i+1], "."), current[key])
 		}
+		current = next
 	}
+	return current, nil
+}
 
-	// Save the updated arrays into the json schema document
-	mixinItemSchema["enum"] = mixinEnumSchema
+// getSchemaArray returns the array at the specified path in a schema.
+func getSchemaArray(schema jsonSchema, path ...string) ([]interface{}, error) {
+	parent, err := getSchemaObject(schema, path[:len(path)-1]...)
+	if err != nil {
+		return nil, err
+	}
 
-	return manifestSchema, err
+	key := path[len(path)-1]
+	array, ok := parent[key].([]interface{})
+	if !ok {
+		return nil, errors.Errorf("root porter manifest schema has invalid %s type, expected []interface{} but got %T", strings.Join(path, "."), parent[key])
+	}
+	return array, nil
+}
+
+// appendSchemaArray appends a value, or a list of values, to the array at the specified path in a schema.
+func appendSchemaArray(schema jsonSchema, value interface{}, path ...string) error {
+	array, err := getSchemaArray(schema, path...)
+	if err != nil {
+		return err
+	}
+
+	if values, ok := value.([]interface{}); ok {
+		array = append(array, values...)
+	} else {
+		array = append(array, value)
+	}
+
+	parent, _ := getSchemaObject(schema, path[:len(path)-1]...)
+	parent[path[len(path)-1]] = array
+	return nil
 }
 
 func (p *Porter) GetReplacementSchema() (jsonSchema, error) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"get.porter.sh/porter/pkg/claims"
 	"get.porter.sh/porter/pkg/context"
 	"get.porter.sh/porter/pkg/printer"
 	dtprinter "github.com/carolynvs/datetime-printer"
@@ -69,7 +70,23 @@ func (p *Porter) ShowInstances(opts ShowOptions) error {
 			fmt.Fprint(p.Out, "Outputs:\n")
 
 			outputs := p.ListBundleOutputs(c, opts.Format)
-			return p.printOutputsTable(outputs)
+			err = p.printOutputsTable(outputs)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Print the installations that share this installation as a dependency, if any
+		dependents, err := claims.ReadDependents(c)
+		if err != nil {
+			return err
+		}
+		if len(dependents) > 0 {
+			fmt.Fprintln(p.Out)
+			fmt.Fprint(p.Out, "Dependents:\n")
+			for _, d := range dependents {
+				fmt.Fprintf(p.Out, "  %s (%s)\n", d.Installation, d.Alias)
+			}
 		}
 		return nil
 	default:
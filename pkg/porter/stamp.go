@@ -22,7 +22,8 @@ func (p *Porter) ensureLocalBundleIsUpToDate(opts bundleFileOptions) error {
 
 	if !upToDate {
 		fmt.Fprintln(p.Out, "Building bundle ===>")
-		return p.Build(BuildOptions{})
+		// Don't fail an action because a mixin doesn't have a schema, only an explicit build is that strict
+		return p.Build(BuildOptions{AllowMissingSchemas: true})
 	}
 	return nil
 }
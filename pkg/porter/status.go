@@ -0,0 +1,288 @@
+package porter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"get.porter.sh/porter/pkg/claims"
+	cnabprovider "get.porter.sh/porter/pkg/cnab/provider"
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/printer"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+)
+
+const (
+	// InstanceStatusOK is the status of a bundle instance whose live outputs match the outputs in its claim.
+	InstanceStatusOK = "ok"
+
+	// InstanceStatusDrifted is the status of a bundle instance whose live outputs are different from the
+	// outputs in its claim.
+	InstanceStatusDrifted = "drifted"
+
+	// InstanceStatusUnsupported is the status of a bundle instance whose bundle does not define a status action.
+	InstanceStatusUnsupported = "unsupported"
+
+	// InstanceStatusFailed is the status of a bundle instance whose status action failed.
+	InstanceStatusFailed = "failed"
+)
+
+// StatusOptions represent options for checking the status of bundle instances.
+type StatusOptions struct {
+	sharedOptions
+	printer.PrintOptions
+
+	// All checks the status of every bundle instance.
+	All bool
+}
+
+// Validate prepares for the status action and validates the args/options.
+func (o *StatusOptions) Validate(args []string, cxt *context.Context) error {
+	if o.All {
+		if len(args) > 0 {
+			return errors.New("an instance name cannot be specified with --all")
+		}
+	} else {
+		err := o.sharedOptions.validateInstanceName(args)
+		if err != nil {
+			return err
+		}
+
+		err = o.sharedOptions.defaultBundleFiles(cxt)
+		if err != nil {
+			return err
+		}
+	}
+
+	o.defaultDriver()
+	err := o.validateDriver()
+	if err != nil {
+		return err
+	}
+
+	return o.ParseFormat()
+}
+
+// InstanceStatus is the status of a bundle instance, reported by the status action of its bundle.
+type InstanceStatus struct {
+	// Name of the bundle instance.
+	Name string `json:"name"`
+
+	// Status is ok when the live outputs match the outputs in the claim, or drifted when they don't.
+	// Unsupported when the bundle does not define a status action, and failed when the action failed.
+	Status string `json:"status"`
+
+	// Message explains why the status could not be checked.
+	Message string `json:"message,omitempty"`
+
+	// Outputs of the status action, compared with the outputs in the claim.
+	Outputs []OutputDrift `json:"outputs,omitempty"`
+}
+
+// OutputDrift compares the live value of an output, reported by the status action, with the value
+// recorded in the claim. The values of sensitive outputs are redacted.
+type OutputDrift struct {
+	// Name of the output.
+	Name string `json:"name"`
+
+	// Recorded is the value of the output in the claim.
+	Recorded interface{} `json:"recorded,omitempty"`
+
+	// Live is the value of the output reported by the status action.
+	Live interface{} `json:"live,omitempty"`
+
+	// Drifted is true when the live value is different from the recorded value, or the
+	// status action did not report an output that was recorded.
+	Drifted bool `json:"drifted"`
+}
+
+// ShowInstanceStatus executes the status action of a bundle instance, or of every bundle
+// instance, and compares the live outputs with the outputs recorded in its claim. Outputs
+// that were not recorded, such as health checks, are reported without being compared.
+func (p *Porter) ShowInstanceStatus(opts StatusOptions) error {
+	err := p.applyDefaultOptions(&opts.sharedOptions)
+	if err != nil {
+		return err
+	}
+
+	var statuses []InstanceStatus
+	if opts.All {
+		instances, err := p.Claims.ReadAll()
+		if err != nil {
+			return errors.Wrap(err, "could not list bundle instances")
+		}
+		sort.Slice(instances, func(i, j int) bool {
+			return instances[i].Name < instances[j].Name
+		})
+
+		statuses = make([]InstanceStatus, 0, len(instances))
+		for _, c := range instances {
+			statuses = append(statuses, p.checkInstanceStatus(c, opts))
+		}
+	} else {
+		c, err := p.Claims.Read(opts.Name)
+		if err != nil {
+			return err
+		}
+
+		status := p.checkInstanceStatus(c, opts)
+		if status.Status == InstanceStatusUnsupported || status.Status == InstanceStatusFailed {
+			return errors.Errorf("could not check the status of bundle instance %s: %s", c.Name, status.Message)
+		}
+		statuses = []InstanceStatus{status}
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		if opts.All {
+			return printer.PrintJson(p.Out, statuses)
+		}
+		return printer.PrintJson(p.Out, statuses[0])
+	case printer.FormatYaml:
+		if opts.All {
+			return printer.PrintYaml(p.Out, statuses)
+		}
+		return printer.PrintYaml(p.Out, statuses[0])
+	case printer.FormatTable:
+		if len(statuses) == 0 {
+			fmt.Fprintln(p.Out, "No bundle instances found")
+			return nil
+		}
+		for i, status := range statuses {
+			if i > 0 {
+				fmt.Fprintln(p.Out)
+			}
+			p.printInstanceStatus(status)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid format: %s", opts.Format)
+	}
+}
+
+// checkInstanceStatus executes the status action of a bundle instance and compares its outputs
+// with the outputs of the claim.
+func (p *Porter) checkInstanceStatus(c claim.Claim, opts StatusOptions) InstanceStatus {
+	status := InstanceStatus{Name: c.Name}
+
+	if c.Bundle == nil {
+		status.Status = InstanceStatusUnsupported
+		status.Message = "the bundle was not recorded"
+		return status
+	}
+	if _, ok := c.Bundle.Actions[cnabprovider.StatusAction]; !ok {
+		status.Status = InstanceStatusUnsupported
+		status.Message = fmt.Sprintf("bundle %s does not define a %s action", c.Bundle.Name, cnabprovider.StatusAction)
+		return status
+	}
+
+	if opts.All {
+		fmt.Fprintf(p.Err, "checking the status of %s...\n", c.Name)
+	}
+	live, err := p.CNAB.Status(cnabprovider.ActionArguments{
+		Claim:                 c.Name,
+		CredentialIdentifiers: opts.CredentialIdentifiers,
+		Driver:                opts.Driver,
+	})
+	if err != nil {
+		status.Status = InstanceStatusFailed
+		status.Message = err.Error()
+		return status
+	}
+
+	status.Outputs = compareOutputs(c, live)
+	status.Status = InstanceStatusOK
+	for _, output := range status.Outputs {
+		if output.Drifted {
+			status.Status = InstanceStatusDrifted
+			break
+		}
+	}
+	return status
+}
+
+// compareOutputs compares the live outputs of the status action with the outputs of the claim.
+// Only the outputs that apply to the status action are compared.
+func compareOutputs(c claim.Claim, live map[string]interface{}) []OutputDrift {
+	names := make([]string, 0, len(c.Bundle.Outputs))
+	for name, output := range c.Bundle.Outputs {
+		if output.AppliesTo(cnabprovider.StatusAction) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	outputs := make([]OutputDrift, 0, len(names))
+	for _, name := range names {
+		recorded, isRecorded := c.Outputs[name]
+		value, isLive := live[name]
+		if !isRecorded && !isLive {
+			continue
+		}
+
+		output := OutputDrift{
+			Name:     name,
+			Recorded: recorded,
+			Live:     value,
+		}
+		if isRecorded {
+			// Outputs are read from files, which may or may not end with a newline
+			output.Drifted = !isLive || strings.TrimSpace(fmt.Sprintf("%v", recorded)) != strings.TrimSpace(fmt.Sprintf("%v", value))
+		}
+
+		def, ok := c.Bundle.Definitions[c.Bundle.Outputs[name].Definition]
+		if ok && def.WriteOnly != nil && *def.WriteOnly {
+			if isRecorded {
+				output.Recorded = claims.RedactedValue
+			}
+			if isLive {
+				output.Live = claims.RedactedValue
+			}
+		}
+
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
+func (p *Porter) printInstanceStatus(status InstanceStatus) {
+	fmt.Fprintf(p.Out, "Name: %s\n", status.Name)
+	fmt.Fprintf(p.Out, "Status: %s\n", status.Status)
+	if status.Message != "" {
+		fmt.Fprintf(p.Out, "Message: %s\n", status.Message)
+	}
+	if len(status.Outputs) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.Out)
+	fmt.Fprint(p.Out, "Outputs:\n")
+
+	table := tablewriter.NewWriter(p.Out)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetBorders(tablewriter.Border{Left: false, Right: false, Bottom: false, Top: true})
+	table.SetAutoFormatHeaders(false)
+
+	table.SetHeader([]string{"Name", "Recorded", "Live", "Drift"})
+	for _, output := range status.Outputs {
+		drift := "no"
+		if output.Drifted {
+			drift = "yes"
+		}
+		table.Append([]string{output.Name, formatDriftValue(output.Recorded), formatDriftValue(output.Live), drift})
+	}
+	table.Render()
+}
+
+// formatDriftValue formats the value of an output for the status table, truncated to a reasonable length.
+func formatDriftValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return truncateString(strings.TrimSpace(fmt.Sprintf("%v", value)), 40)
+}
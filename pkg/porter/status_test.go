@@ -0,0 +1,194 @@
+package porter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"get.porter.sh/porter/pkg/claims"
+	cnabprovider "get.porter.sh/porter/pkg/cnab/provider"
+	"get.porter.sh/porter/pkg/printer"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusCNABProvider returns the live outputs of each installation from its status action.
+type statusCNABProvider struct {
+	*TestCNABProvider
+	outputs map[string]map[string]interface{}
+}
+
+func (c *statusCNABProvider) Status(args cnabprovider.ActionArguments) (map[string]interface{}, error) {
+	outputs, ok := c.outputs[args.Claim]
+	if !ok {
+		return nil, errors.New("failed to run the status action")
+	}
+	return outputs, nil
+}
+
+func newStatusBundle(withStatus bool) *bundle.Bundle {
+	writeOnly := true
+	bun := &bundle.Bundle{
+		Name: "mysql",
+		Definitions: definition.Definitions{
+			"string":   &definition.Schema{Type: "string"},
+			"password": &definition.Schema{Type: "string", WriteOnly: &writeOnly},
+		},
+		Outputs: map[string]bundle.Output{
+			"endpoint": {Definition: "string", Path: "/cnab/app/outputs/endpoint"},
+			"password": {Definition: "password", Path: "/cnab/app/outputs/password"},
+			"healthy":  {Definition: "string", Path: "/cnab/app/outputs/healthy", ApplyTo: []string{"status"}},
+			"logs":     {Definition: "string", Path: "/cnab/app/outputs/logs", ApplyTo: []string{"install"}},
+		},
+		Actions: map[string]bundle.Action{},
+	}
+	if withStatus {
+		bun.Actions["status"] = bundle.Action{Description: "Check the status"}
+	}
+	return bun
+}
+
+func saveStatusClaim(t *testing.T, p *TestPorter, name string, bun *bundle.Bundle) {
+	c, err := claim.New(name)
+	require.NoError(t, err)
+	c.Bundle = bun
+	c.Update(claim.ActionInstall, claim.StatusSuccess)
+	c.Outputs = map[string]interface{}{
+		"endpoint": "10.0.0.1\n",
+		"password": "secret",
+		"logs":     "installed",
+	}
+	require.NoError(t, p.Claims.Save(*c))
+}
+
+func TestStatusOptions_Validate(t *testing.T) {
+	p := NewTestPorter(t)
+
+	opts := StatusOptions{All: true}
+	err := opts.Validate([]string{"mysql"}, p.Context)
+	require.EqualError(t, err, "an instance name cannot be specified with --all")
+
+	opts = StatusOptions{All: true}
+	opts.RawFormat = "json"
+	require.NoError(t, opts.Validate(nil, p.Context))
+	assert.Equal(t, DefaultDriver, opts.Driver)
+	assert.Equal(t, printer.FormatJson, opts.Format)
+}
+
+func TestCompareOutputs(t *testing.T) {
+	c := claim.Claim{
+		Bundle: newStatusBundle(true),
+		Outputs: map[string]interface{}{
+			"endpoint": "10.0.0.1\n",
+			"password": "secret",
+			"logs":     "installed",
+		},
+	}
+
+	testcases := []struct {
+		name string
+		live map[string]interface{}
+		want []OutputDrift
+	}{
+		{
+			name: "no drift",
+			live: map[string]interface{}{"endpoint": "10.0.0.1", "password": "secret", "healthy": "true"},
+			want: []OutputDrift{
+				{Name: "endpoint", Recorded: "10.0.0.1\n", Live: "10.0.0.1"},
+				{Name: "healthy", Live: "true"},
+				{Name: "password", Recorded: claims.RedactedValue, Live: claims.RedactedValue},
+			},
+		},
+		{
+			name: "changed",
+			live: map[string]interface{}{"endpoint": "10.0.0.2", "password": "changed"},
+			want: []OutputDrift{
+				{Name: "endpoint", Recorded: "10.0.0.1\n", Live: "10.0.0.2", Drifted: true},
+				{Name: "password", Recorded: claims.RedactedValue, Live: claims.RedactedValue, Drifted: true},
+			},
+		},
+		{
+			name: "missing",
+			live: map[string]interface{}{"password": "secret"},
+			want: []OutputDrift{
+				{Name: "endpoint", Recorded: "10.0.0.1\n", Drifted: true},
+				{Name: "password", Recorded: claims.RedactedValue, Live: claims.RedactedValue},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := compareOutputs(c, tc.live)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPorter_ShowInstanceStatus(t *testing.T) {
+	p := NewTestPorter(t)
+	p.Debug = false
+	p.CNAB = &statusCNABProvider{
+		TestCNABProvider: NewTestCNABProvider(),
+		outputs: map[string]map[string]interface{}{
+			"mysql": {"endpoint": "10.0.0.2", "password": "secret", "healthy": "true"},
+		},
+	}
+	saveStatusClaim(t, p, "mysql", newStatusBundle(true))
+
+	opts := StatusOptions{}
+	opts.Name = "mysql"
+	opts.Format = printer.FormatJson
+
+	err := p.ShowInstanceStatus(opts)
+	require.NoError(t, err)
+
+	var status InstanceStatus
+	require.NoError(t, json.Unmarshal([]byte(p.TestConfig.TestContext.GetOutput()), &status))
+	assert.Equal(t, "mysql", status.Name)
+	assert.Equal(t, InstanceStatusDrifted, status.Status)
+	require.Len(t, status.Outputs, 3)
+	assert.Equal(t, OutputDrift{Name: "endpoint", Recorded: "10.0.0.1\n", Live: "10.0.0.2", Drifted: true}, status.Outputs[0])
+}
+
+func TestPorter_ShowInstanceStatus_Unsupported(t *testing.T) {
+	p := NewTestPorter(t)
+	saveStatusClaim(t, p, "mysql", newStatusBundle(false))
+
+	opts := StatusOptions{}
+	opts.Name = "mysql"
+	opts.Format = printer.FormatTable
+
+	err := p.ShowInstanceStatus(opts)
+	require.EqualError(t, err, "could not check the status of bundle instance mysql: bundle mysql does not define a status action")
+}
+
+func TestPorter_ShowInstanceStatus_All(t *testing.T) {
+	p := NewTestPorter(t)
+	p.Debug = false
+	p.CNAB = &statusCNABProvider{
+		TestCNABProvider: NewTestCNABProvider(),
+		outputs: map[string]map[string]interface{}{
+			"mysql": {"endpoint": "10.0.0.1", "password": "secret", "healthy": "true"},
+		},
+	}
+	saveStatusClaim(t, p, "mysql", newStatusBundle(true))
+	saveStatusClaim(t, p, "legacy", newStatusBundle(false))
+	saveStatusClaim(t, p, "broken", newStatusBundle(true))
+
+	opts := StatusOptions{All: true}
+	opts.Format = printer.FormatTable
+
+	err := p.ShowInstanceStatus(opts)
+	require.NoError(t, err)
+
+	gotOutput := p.TestConfig.TestContext.GetOutput()
+	assert.Contains(t, gotOutput, "Name: broken\nStatus: failed\nMessage: failed to run the status action\n")
+	assert.Contains(t, gotOutput, "Name: legacy\nStatus: unsupported\nMessage: bundle mysql does not define a status action\n")
+	assert.Contains(t, gotOutput, "Name: mysql\nStatus: ok\n")
+	assert.Contains(t, gotOutput, "  endpoint  10.0.0.1  10.0.0.1  no")
+	assert.Contains(t, gotOutput, "  password  ******    ******    no")
+}
@@ -2,6 +2,9 @@ package porter
 
 import (
 	"fmt"
+	"strings"
+
+	"get.porter.sh/porter/pkg/claims"
 
 	"get.porter.sh/porter/pkg/manifest"
 
@@ -32,6 +35,8 @@ func (p *Porter) UninstallBundle(opts UninstallOptions) error {
 		return err
 	}
 
+	p.warnSharedInstallation(opts.Name)
+
 	deperator := newDependencyExecutioner(p)
 	err = deperator.Prepare(opts.BundleLifecycleOpts, p.CNAB.Uninstall)
 	if err != nil {
@@ -41,13 +46,33 @@ func (p *Porter) UninstallBundle(opts UninstallOptions) error {
 	fmt.Fprintf(p.Out, "uninstalling %s...\n", opts.Name)
 	err = p.CNAB.Uninstall(opts.ToActionArgs(deperator))
 	if err != nil {
-		if len(deperator.deps) > 0 {
+		if len(deperator.deps) > 0 && !opts.SkipDependencies {
 			return errors.Wrapf(err, "failed to uninstall the %s bundle, the remaining dependencies were not uninstalled", opts.Name)
 		} else {
 			return err
 		}
 	}
 
-	// TODO: See https://github.com/deislabs/porter/issues/465 for flag to allow keeping around the dependencies
+	// Dependencies are uninstalled after the parent, in the reverse order that they were installed
 	return deperator.Execute(manifest.ActionUninstall)
 }
+
+// warnSharedInstallation warns when other installations still reference the
+// installation as a shared dependency.
+func (p *Porter) warnSharedInstallation(name string) {
+	c, err := p.Claims.Read(name)
+	if err != nil {
+		return
+	}
+
+	dependents, err := claims.ReadDependents(c)
+	if err != nil || len(dependents) == 0 {
+		return
+	}
+
+	refs := make([]string, len(dependents))
+	for i, d := range dependents {
+		refs[i] = fmt.Sprintf("%s (%s)", d.Installation, d.Alias)
+	}
+	fmt.Fprintf(p.Err, "WARNING: %s is a shared dependency of %s, which will no longer be able to use it\n", name, strings.Join(refs, ", "))
+}
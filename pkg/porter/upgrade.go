@@ -32,6 +32,11 @@ func (p *Porter) UpgradeBundle(opts UpgradeOptions) error {
 		return err
 	}
 
+	err = p.promptForParameters(&opts.BundleLifecycleOpts, string(manifest.ActionUpgrade))
+	if err != nil {
+		return err
+	}
+
 	deperator := newDependencyExecutioner(p)
 	err = deperator.Prepare(opts.BundleLifecycleOpts, p.CNAB.Upgrade)
 	if err != nil {
@@ -43,6 +48,14 @@ func (p *Porter) UpgradeBundle(opts UpgradeOptions) error {
 		return err
 	}
 
+	args := opts.ToActionArgs(deperator)
+	if opts.Resume {
+		args.Resume, err = p.resumeInstallation(opts.Name, manifest.ActionUpgrade)
+		if err != nil {
+			return err
+		}
+	}
+
 	fmt.Fprintf(p.Out, "upgrading %s...\n", opts.Name)
-	return p.CNAB.Upgrade(opts.ToActionArgs(deperator))
+	return p.CNAB.Upgrade(args)
 }
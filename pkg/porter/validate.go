@@ -0,0 +1,245 @@
+package porter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/manifest"
+	"get.porter.sh/porter/pkg/parameters"
+	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v2"
+)
+
+// ValidateOptions are the options that may be specified when validating a porter manifest.
+type ValidateOptions struct {
+	bundleFileOptions
+
+	// AllowMissingSchemas skips validating the steps of mixins that do not provide a schema.
+	AllowMissingSchemas bool
+}
+
+func (o *ValidateOptions) Validate(cxt *context.Context) error {
+	err := o.bundleFileOptions.Validate(cxt)
+	if err != nil {
+		return err
+	}
+
+	if o.File == "" {
+		return errors.New("could not find porter.yaml in the current directory, make sure you are in the right directory or specify the porter manifest with --file")
+	}
+
+	return nil
+}
+
+// ValidateManifest validates the porter manifest, and the files that it includes,
+// against the porter manifest schema and then checks the manifest, and the templates
+// in its steps, are valid.
+func (p *Porter) ValidateManifest(opts ValidateOptions) error {
+	m, err := manifest.ReadManifest(p.Context, opts.File)
+	if err != nil {
+		return err
+	}
+
+	err = p.validateManifestSchema(m, opts.AllowMissingSchemas)
+	if err != nil {
+		return err
+	}
+
+	err = m.Validate()
+	if err != nil {
+		return err
+	}
+
+	// The dependencies are not resolved, so any of their parameters and outputs may be referenced
+	err = m.ValidateTemplates(nil)
+	if err != nil {
+		return errors.Wrap(err, "invalid template in the manifest")
+	}
+
+	fmt.Fprintf(p.Out, "%s is valid\n", opts.File)
+	return nil
+}
+
+// schemaError is a problem found when validating a file against the porter manifest schema.
+type schemaError struct {
+	file    string
+	line    int
+	field   string
+	message string
+}
+
+func (e schemaError) String() string {
+	location := e.file
+	if e.line > 0 {
+		location = fmt.Sprintf("%s:%d", e.file, e.line)
+	}
+	if e.field == "" {
+		return fmt.Sprintf("%s: %s", location, e.message)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, e.field, e.message)
+}
+
+// validateManifestSchema validates the manifest, and the files that it includes, against the porter
+// manifest schema, with the schema of each mixin. Each file is validated separately, so that the
+// errors refer to the line in the file where they occur.
+func (p *Porter) validateManifestSchema(m *manifest.Manifest, allowMissingSchemas bool) error {
+	manifestSchema, err := p.getValidationSchema(m, allowMissingSchemas)
+	if err != nil {
+		return err
+	}
+
+	// The required fields only need to be defined by one of the files
+	required, _ := manifestSchema["required"].([]interface{})
+	delete(manifestSchema, "required")
+
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(manifestSchema))
+	if err != nil {
+		return errors.Wrap(err, "could not load the porter manifest schema")
+	}
+
+	var problems []schemaError
+	definedFields := map[string]bool{}
+	for _, file := range m.Files {
+		doc := make(map[string]interface{})
+		err = yaml.Unmarshal(file.Data, &doc)
+		if err != nil {
+			return errors.Wrapf(err, "could not unmarshal %s", file.Location)
+		}
+		for field := range doc {
+			definedFields[field] = true
+		}
+
+		result, err := schema.Validate(gojsonschema.NewGoLoader(parameters.ToJSONCompatible(doc)))
+		if err != nil {
+			return errors.Wrapf(err, "could not validate %s against the porter manifest schema", file.Location)
+		}
+
+		problems = append(problems, getSchemaErrors(file.Location, file.Data, result.Errors())...)
+	}
+
+	for _, field := range required {
+		if !definedFields[fmt.Sprintf("%v", field)] {
+			problems = append(problems, schemaError{file: m.ManifestPath, message: fmt.Sprintf("%v is required", field)})
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(problems))
+	for i, problem := range problems {
+		messages[i] = problem.String()
+	}
+	return errors.Errorf("the manifest does not match the porter manifest schema:\n%s", strings.Join(messages, "\n"))
+}
+
+// getValidationSchema returns the porter manifest schema used to validate the manifest.
+// A mixin used by the manifest that does not provide a schema is an error, unless
+// allowMissingSchemas is set, in which case the steps using the mixin are not validated.
+func (p *Porter) getValidationSchema(m *manifest.Manifest, allowMissingSchemas bool) (jsonSchema, error) {
+	replacementSchema, err := p.GetReplacementSchema()
+	if err != nil {
+		return nil, err
+	}
+	if replacementSchema != nil {
+		return replacementSchema, nil
+	}
+
+	manifestSchema, err := p.getRootManifestSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	manifestSchema, err = p.injectMixinSchemas(manifestSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build the porter manifest schema")
+	}
+
+	for _, mixin := range m.Mixins {
+		if _, ok := manifestSchema["mixin."+mixin.Name]; ok {
+			continue
+		}
+
+		if !allowMissingSchemas {
+			return nil, errors.Errorf("could not get the schema for the %s mixin, so its steps cannot be validated. Make sure that the mixin is installed, or use --allow-missing-schemas to skip validating its steps", mixin.Name)
+		}
+
+		err = injectMixinSchema(manifestSchema, mixin.Name, buildMissingMixinSchema(mixin.Name))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not build the porter manifest schema")
+		}
+	}
+
+	return manifestSchema, nil
+}
+
+// buildMissingMixinSchema creates a schema for a mixin that does not provide one,
+// which allows a step to use the mixin with any data.
+func buildMissingMixinSchema(mixinName string) jsonSchema {
+	definitions := jsonSchema{}
+	for _, definition := range mixinStepDefinitions {
+		definitions[definition] = jsonSchema{
+			"type":       "object",
+			"properties": jsonSchema{mixinName: jsonSchema{}},
+			"required":   []interface{}{mixinName},
+		}
+	}
+	return jsonSchema{"definitions": definitions}
+}
+
+// getSchemaErrors converts the errors from validating a file against the porter manifest
+// schema into schemaErrors, sorted by line.
+func getSchemaErrors(file string, data []byte, results []gojsonschema.ResultError) []schemaError {
+	var problems []schemaError
+	for _, result := range results {
+		field := result.Field()
+		if field == "(root)" {
+			field = ""
+		}
+
+		// When a step doesn't match any of the allowed steps, the errors from the closest
+		// match are also reported, which are more useful than reporting the step didn't match
+		if result.Type() == "number_any_of" && hasNestedSchemaError(field, results) {
+			continue
+		}
+
+		var path []string
+		if field != "" {
+			path = strings.Split(field, ".")
+		}
+		if property, ok := result.Details()["property"].(string); ok && result.Type() == "additional_property_not_allowed" {
+			path = append(path, property)
+		}
+
+		problems = append(problems, schemaError{
+			file:    file,
+			line:    manifest.FindLine(data, path...),
+			field:   field,
+			message: result.Description(),
+		})
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].line < problems[j].line
+	})
+	return problems
+}
+
+// hasNestedSchemaError determines if there is another error for the field, or a field inside it.
+func hasNestedSchemaError(field string, results []gojsonschema.ResultError) bool {
+	for _, result := range results {
+		if result.Type() == "number_any_of" {
+			continue
+		}
+
+		nested := result.Field()
+		if field == "" || nested == field || strings.HasPrefix(nested, field+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package porter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"get.porter.sh/porter/pkg/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPorter_ValidateManifest(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+	p.TestConfig.TestContext.AddTestFile("testdata/porter.yaml", "porter.yaml")
+
+	opts := ValidateOptions{bundleFileOptions: bundleFileOptions{File: "porter.yaml"}}
+	err := p.ValidateManifest(opts)
+	require.NoError(t, err)
+	assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "porter.yaml is valid")
+}
+
+func TestPorter_ValidateManifest_StepErrors(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+	p.TestConfig.TestContext.AddTestFile("testdata/validate/porter.yaml", "porter.yaml")
+
+	opts := ValidateOptions{bundleFileOptions: bundleFileOptions{File: "porter.yaml"}}
+	err := p.ValidateManifest(opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "porter.yaml:11: install.0.exec: Additional property comand is not allowed")
+	assert.Contains(t, err.Error(), "porter.yaml:9: install.0.exec: command is required")
+	assert.NotContains(t, err.Error(), "Must validate at least one schema")
+}
+
+func TestPorter_ValidateManifest_StepKeywords(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+	p.TestConfig.TestContext.AddTestDirectory("testdata/validate", ".")
+
+	opts := ValidateOptions{bundleFileOptions: bundleFileOptions{File: "steps.yaml"}}
+	err := p.ValidateManifest(opts)
+	require.NoError(t, err)
+}
+
+func TestPorter_ValidateManifest_IncludedFile(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+	p.TestConfig.TestContext.AddTestDirectory("testdata/validate", ".")
+
+	opts := ValidateOptions{bundleFileOptions: bundleFileOptions{File: "include-error.yaml"}}
+	err := p.ValidateManifest(opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad-actions.yaml:2: uninstall.0.retries: Must be greater than or equal to 0")
+	assert.NotContains(t, err.Error(), "include-error.yaml:")
+}
+
+func TestPorter_ValidateManifest_URL(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+
+	// The manifest and the file that it includes are only available from the server
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata/validate")))
+	defer ts.Close()
+
+	opts := ValidateOptions{bundleFileOptions: bundleFileOptions{File: ts.URL + "/include-error.yaml"}}
+	err := p.ValidateManifest(opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ts.URL+"/bad-actions.yaml:2: uninstall.0.retries: Must be greater than or equal to 0")
+}
+
+func TestPorter_ValidateManifest_RequiredFields(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+	p.TestConfig.TestContext.AddTestDirectory("testdata/validate", ".")
+
+	// Only validate the file that is usually included, which doesn't define the required fields
+	data, err := p.FileSystem.ReadFile("bad-actions.yaml")
+	require.NoError(t, err)
+	m := &manifest.Manifest{
+		ManifestPath: "bad-actions.yaml",
+		Files:        []manifest.ManifestFile{{Location: "bad-actions.yaml", Data: data}},
+	}
+	err = p.validateManifestSchema(m, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad-actions.yaml: name is required")
+	assert.Contains(t, err.Error(), "bad-actions.yaml: mixins is required")
+}
+
+func TestPorter_ValidateManifest_MissingMixinSchema(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+	p.TestConfig.TestContext.AddTestDirectory("testdata/validate", ".")
+
+	t.Run("not allowed", func(t *testing.T) {
+		opts := ValidateOptions{bundleFileOptions: bundleFileOptions{File: "missing-mixin.yaml"}}
+		err := p.ValidateManifest(opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "could not get the schema for the helm mixin")
+		assert.Contains(t, err.Error(), "--allow-missing-schemas")
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		opts := ValidateOptions{
+			bundleFileOptions:   bundleFileOptions{File: "missing-mixin.yaml"},
+			AllowMissingSchemas: true,
+		}
+		err := p.ValidateManifest(opts)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,90 @@
+package runtime
+
+import (
+	"strings"
+
+	"get.porter.sh/porter/pkg/manifest"
+	"github.com/cbroglie/mustache"
+	"github.com/pkg/errors"
+)
+
+// conditionOperators are the comparisons supported in a step condition, in the
+// order that they are matched.
+var conditionOperators = []string{"!=", "=="}
+
+// falseConditionValues are the rendered values of a step condition, without a
+// comparison, that are not true.
+var falseConditionValues = []string{"", "false", "0", "no", "off"}
+
+// EvaluateCondition determines if a step should be executed, based on the
+// step's condition. Steps without a condition are always executed.
+//
+// A condition is either a single template, for example
+// "{{ bundle.parameters.backup }}", which is true unless it renders to an empty
+// string, false, 0, no or off, or a comparison of two values using == or !=,
+// for example "{{ bundle.parameters.env }} == prod".
+func (m *RuntimeManifest) EvaluateCondition(step *manifest.Step) (bool, error) {
+	condition := strings.TrimSpace(step.If)
+	if condition == "" {
+		return true, nil
+	}
+
+	sourceData, err := m.buildSourceData()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to build step template data")
+	}
+
+	// Split the comparison before rendering so that a rendered value can't change the operator
+	for _, op := range conditionOperators {
+		i := strings.Index(condition, op)
+		if i < 0 {
+			continue
+		}
+
+		left, err := m.renderConditionValue(condition[:i], sourceData)
+		if err != nil {
+			return false, errors.Wrapf(err, "unable to render the step condition %q", step.If)
+		}
+
+		right, err := m.renderConditionValue(condition[i+len(op):], sourceData)
+		if err != nil {
+			return false, errors.Wrapf(err, "unable to render the step condition %q", step.If)
+		}
+
+		if op == "==" {
+			return left == right, nil
+		}
+		return left != right, nil
+	}
+
+	value, err := m.renderConditionValue(condition, sourceData)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to render the step condition %q", step.If)
+	}
+
+	for _, f := range falseConditionValues {
+		if strings.EqualFold(value, f) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// renderConditionValue renders one side of a step condition, removing any
+// surrounding whitespace and quotes.
+func (m *RuntimeManifest) renderConditionValue(tmpl string, sourceData map[string]interface{}) (string, error) {
+	mustache.AllowMissingVariables = false
+	rendered, err := mustache.RenderRaw(strings.TrimSpace(tmpl), true, sourceData)
+	if err != nil {
+		return "", err
+	}
+
+	rendered = strings.TrimSpace(rendered)
+	if len(rendered) >= 2 {
+		first, last := rendered[0], rendered[len(rendered)-1]
+		if first == last && (first == '"' || first == '\'') {
+			rendered = rendered[1 : len(rendered)-1]
+		}
+	}
+	return rendered, nil
+}
@@ -0,0 +1,106 @@
+package runtime
+
+import (
+	"os"
+	"testing"
+
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/manifest"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeManifest_EvaluateCondition(t *testing.T) {
+	testcases := []struct {
+		name      string
+		condition string
+		want      bool
+	}{
+		{"no condition", "", true},
+		{"true parameter", "{{ bundle.parameters.backup }}", true},
+		{"false parameter", "{{ bundle.parameters.cleanup }}", false},
+		{"empty parameter", "{{ bundle.parameters.empty }}", false},
+		{"literal no", "no", false},
+		{"literal OFF", "OFF", false},
+		{"literal 0", "0", false},
+		{"equal", "{{ bundle.parameters.env }} == prod", true},
+		{"equal quoted", "'{{ bundle.parameters.env }}' == \"prod\"", true},
+		{"not equal", "{{ bundle.parameters.env }} != prod", false},
+		{"compare outputs", "{{ bundle.outputs.status }} == ready", true},
+		{"compare dependency outputs", "{{ bundle.dependencies.mysql.outputs.host }} != localhost", true},
+		{"rendered operator is not a comparison", "{{ bundle.parameters.tricky }}", true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cxt := context.NewTestContext(t)
+			m := &manifest.Manifest{
+				Parameters: []manifest.ParameterDefinition{
+					{Name: "backup"},
+					{Name: "cleanup"},
+					{Name: "empty"},
+					{Name: "env"},
+					{Name: "tricky"},
+				},
+			}
+			rm := NewRuntimeManifest(cxt.Context, manifest.ActionInstall, m)
+			rm.outputs = map[string]string{"status": "ready"}
+			rm.bundles = map[string]bundle.Bundle{
+				"mysql": {
+					Definitions: definition.Definitions{
+						"host": &definition.Schema{Type: "string"},
+					},
+					Outputs: map[string]bundle.Output{
+						"host": {Definition: "host"},
+					},
+				},
+			}
+			cxt.FileSystem.WriteFile("/cnab/app/dependencies/mysql/outputs/host", []byte("mysql.example.com"), 0644)
+
+			for name, value := range map[string]string{
+				"BACKUP":  "true",
+				"CLEANUP": "false",
+				"EMPTY":   "",
+				"ENV":     "prod",
+				"TRICKY":  "a == b",
+			} {
+				os.Setenv(name, value)
+				defer os.Unsetenv(name)
+			}
+
+			step := &manifest.Step{
+				If: tc.condition,
+				Data: map[string]interface{}{
+					"exec": map[interface{}]interface{}{
+						"description": "a test step",
+					},
+				},
+			}
+
+			got, err := rm.EvaluateCondition(step)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRuntimeManifest_EvaluateCondition_MissingVariable(t *testing.T) {
+	cxt := context.NewTestContext(t)
+	rm := NewRuntimeManifest(cxt.Context, manifest.ActionInstall, &manifest.Manifest{})
+
+	step := &manifest.Step{
+		If: "{{ bundle.parameters.nope }} == true",
+		Data: map[string]interface{}{
+			"exec": map[interface{}]interface{}{
+				"description": "a test step",
+			},
+		},
+	}
+
+	_, err := rm.EvaluateCondition(step)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unable to render the step condition "{{ bundle.parameters.nope }} == true"`)
+	assert.Contains(t, err.Error(), `Missing variable "nope"`)
+}
@@ -20,3 +20,29 @@ func GetDependencyDefinition(c *context.Context, alias string) ([]byte, error) {
 	data, err := c.FileSystem.ReadFile(f)
 	return data, errors.Wrapf(err, "error reading bundle definition for %s at %s", alias, f)
 }
+
+// GetDependencyParametersDir determines the parameters directory for a dependency
+func GetDependencyParametersDir(alias string) string {
+	return filepath.Join(BundleDependenciesDir, alias, "parameters")
+}
+
+// ReadDependencyParameterValue reads the value of a parameter that was passed to the dependency,
+// using the alias for the dependency. Returns false when the parameter value is not available.
+func ReadDependencyParameterValue(c *context.Context, alias string, name string) (string, bool, error) {
+	paramFile := filepath.Join(GetDependencyParametersDir(alias), name)
+
+	exists, err := c.FileSystem.Exists(paramFile)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "unable to read %s", paramFile)
+	}
+	if !exists {
+		return "", false, nil
+	}
+
+	b, err := c.FileSystem.ReadFile(paramFile)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "unable to read %s", paramFile)
+	}
+
+	return string(b), true, nil
+}
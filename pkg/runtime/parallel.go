@@ -0,0 +1,187 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"get.porter.sh/porter/pkg/manifest"
+	"github.com/pkg/errors"
+)
+
+// executeParallelSteps executes the steps in a parallel step group concurrently.
+// When a step fails, the other steps in the group are cancelled. The outputs of
+// the steps are applied once every step in the group has completed.
+func (r *PorterRuntime) executeParallelSteps(id string, group *manifest.Step) error {
+	description, _ := group.GetDescription()
+
+	// The group is skipped when resuming only when every step in the group completed
+	if outputs, ok := r.resumeStep(id, description); ok {
+		fmt.Fprintf(r.Out, "Skipping step %q, it completed in the previous run\n", description)
+		return r.applyCompletedStep(id, description, group, outputs)
+	}
+
+	run, err := r.RuntimeManifest.EvaluateCondition(group)
+	if err != nil {
+		return errors.Wrapf(err, "unable to evaluate the condition of step %q", description)
+	}
+	if !run {
+		fmt.Fprintf(r.Out, "Skipping step %q, its condition %q is not true\n", description, group.If)
+		return nil
+	}
+
+	// Resolve every step before starting any of them, so that a step is not
+	// started when another step in the group is invalid
+	var steps []stepRun
+	for _, step := range group.Parallel {
+		if step == nil {
+			continue
+		}
+
+		stepDescription, _ := step.GetDescription()
+		run, err := r.RuntimeManifest.EvaluateCondition(step)
+		if err != nil {
+			return errors.Wrapf(err, "unable to evaluate the condition of step %q", stepDescription)
+		}
+		if !run {
+			fmt.Fprintf(r.Out, "Skipping step %q, its condition %q is not true\n", stepDescription, step.If)
+			continue
+		}
+
+		err = r.RuntimeManifest.ResolveStep(step)
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve step")
+		}
+
+		stepDescription, _ = step.GetDescription()
+		steps = append(steps, stepRun{step: step, description: stepDescription, parallel: true})
+	}
+
+	if len(steps) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(r.Out, "Running %d steps in parallel\n", len(steps))
+
+	// Hand over values needing masking in context output streams
+	r.Context.SetSensitiveValues(r.RuntimeManifest.GetSensitiveValues())
+
+	cancel := make(chan struct{})
+	var cancelOnce sync.Once
+	var outputLock sync.Mutex
+	errs := make([]error, len(steps))
+
+	var wg sync.WaitGroup
+	for i := range steps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Give each step its own output streams, prefixed with the step description
+			step := steps[i]
+			stepCxt := *r.Context
+			stdout := newPrefixedWriter(r.Out, &outputLock, step.description)
+			stderr := newPrefixedWriter(r.Err, &outputLock, step.description)
+			defer stdout.Flush()
+			defer stderr.Flush()
+			stepCxt.Out = stdout
+			stepCxt.Err = stderr
+			step.Context = &stepCxt
+			step.cancel = cancel
+
+			err := r.runStep(step)
+			if err == nil || err == errStepCancelled {
+				errs[i] = err
+				return
+			}
+
+			if step.step.ContinueOnError {
+				stdout.Flush()
+				fmt.Fprintf(stdout, "Step %q failed, continuing because continueOnError is set: %s\n", step.description, err)
+				return
+			}
+
+			errs[i] = err
+			cancelOnce.Do(func() { close(cancel) })
+		}(i)
+	}
+	wg.Wait()
+
+	var failed error
+	for i, err := range errs {
+		switch {
+		case err == errStepCancelled:
+			fmt.Fprintf(r.Out, "Cancelled step %q\n", steps[i].description)
+		case err != nil && failed == nil:
+			failed = errors.Wrapf(err, "step %q failed", steps[i].description)
+		}
+	}
+
+	if failed != nil {
+		// Discard any outputs from the failed group
+		if _, cleanupErr := r.readMixinOutputs(); cleanupErr != nil {
+			return errors.Wrap(cleanupErr, "could not clean up step outputs")
+		}
+		return errors.Wrap(failed, "mixin execution failed")
+	}
+
+	return r.completeStep(id, description, group)
+}
+
+// prefixedWriter writes each line to the underlying writer prefixed with
+// the name of the step that wrote it. Lines from different steps are not
+// interleaved because writes are serialized by a lock shared by the steps.
+type prefixedWriter struct {
+	out    io.Writer
+	lock   *sync.Mutex
+	prefix []byte
+	buf    bytes.Buffer
+}
+
+func newPrefixedWriter(out io.Writer, lock *sync.Mutex, name string) *prefixedWriter {
+	return &prefixedWriter{
+		out:    out,
+		lock:   lock,
+		prefix: []byte(fmt.Sprintf("[%s] ", name)),
+	}
+}
+
+// Write buffers the data and writes any complete lines.
+func (w *prefixedWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+
+		err := w.writeLine(w.buf.Next(i + 1))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush writes any remaining partial line.
+func (w *prefixedWriter) Flush() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	line := append(w.buf.Bytes(), '\n')
+	w.buf.Reset()
+	return w.writeLine(line)
+}
+
+func (w *prefixedWriter) writeLine(line []byte) error {
+	_, err := w.out.Write(append(append([]byte{}, w.prefix...), line...))
+	return err
+}
@@ -0,0 +1,61 @@
+// +build !windows
+
+package runtime
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/mixin"
+	mixinprovider "get.porter.sh/porter/pkg/mixin/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// processMixinProvider runs each step as a process, instead of a mixin.
+type processMixinProvider struct {
+	*mixin.TestMixinProvider
+
+	// scripts are the shell scripts run for each step, by step description.
+	scripts map[string]string
+}
+
+func (p *processMixinProvider) Run(mixinCxt *context.Context, mixinName string, commandOpts mixin.CommandOptions) error {
+	var script string
+	for description, s := range p.scripts {
+		if strings.Contains(commandOpts.Input, description) {
+			script = s
+		}
+	}
+
+	cxt := *mixinCxt
+	cxt.NewCommand = func(command string, args ...string) *exec.Cmd {
+		return exec.Command("sh", "-c", script)
+	}
+	r := mixinprovider.NewRunner(mixinName, "", commandOpts.Runtime)
+	r.Context = &cxt
+	return r.Run(commandOpts)
+}
+
+func TestPorterRuntime_Execute_ParallelSteps_CancelProcesses(t *testing.T) {
+	r := NewTestPorterRuntime(t)
+	rm, _ := setupParallelSteps(t, r, "")
+	r.mixins = &processMixinProvider{
+		TestMixinProvider: r.mixins.(*mixin.TestMixinProvider),
+		scripts: map[string]string{
+			// The step starts a process that keeps running after the step is killed
+			"Create network":  "sleep 5 & wait",
+			"Create database": "sleep 0.1; exit 1",
+		},
+	}
+
+	start := time.Now()
+	err := r.Execute(rm)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `step "Create database" failed`)
+	assert.Less(t, int64(time.Since(start)), int64(2*time.Second), "the other steps in the group should be stopped when a step fails")
+	assert.Contains(t, r.TestContext.GetOutput(), `Cancelled step "Create network"`)
+}
@@ -0,0 +1,130 @@
+package runtime
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/context"
+	"get.porter.sh/porter/pkg/manifest"
+	"get.porter.sh/porter/pkg/mixin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupParallelSteps prepares a runtime that executes testdata/parallel-steps.yaml and
+// returns the runtime manifest, and a function that lists the steps run by the mixin.
+func setupParallelSteps(t *testing.T, r *TestPorterRuntime, slowStep string) (*RuntimeManifest, func() []string) {
+	r.TestContext.AddTestFile("testdata/parallel-steps.yaml", config.Name)
+	r.TestContext.AddTestFile("testdata/bundle-images.json", "/cnab/bundle.json")
+
+	var lock sync.Mutex
+	var ran []string
+	mixins := r.mixins.(*mixin.TestMixinProvider)
+	mixins.RunAssertions = append(mixins.RunAssertions, func(mixinCxt *context.Context, mixinName string, commandOpts mixin.CommandOptions) {
+		var description string
+		for _, d := range []string{"Create network", "Create database", "Deploy app"} {
+			if strings.Contains(commandOpts.Input, d) {
+				description = d
+			}
+		}
+
+		lock.Lock()
+		ran = append(ran, description)
+		lock.Unlock()
+
+		if description == slowStep {
+			select {
+			case <-time.After(200 * time.Millisecond):
+			case <-commandOpts.Cancel:
+			}
+		}
+
+		switch description {
+		case "Create network":
+			mixinCxt.Out.Write([]byte("creating network\n"))
+			mixinCxt.WriteMixinOutputToFile("network-id", []byte("net-123"))
+		case "Create database":
+			mixinCxt.Out.Write([]byte("creating database"))
+			mixinCxt.WriteMixinOutputToFile("database-id", []byte("db-456"))
+		case "Deploy app":
+			assert.Contains(t, commandOpts.Input, "net-123", "the outputs of the parallel steps should be available to later steps")
+			assert.Contains(t, commandOpts.Input, "db-456", "the outputs of the parallel steps should be available to later steps")
+		}
+	})
+
+	m, err := manifest.LoadManifestFrom(r.Context, config.Name)
+	require.NoError(t, err)
+	rm := NewRuntimeManifest(r.Context, manifest.ActionInstall, m)
+
+	return rm, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string{}, ran...)
+	}
+}
+
+func TestPorterRuntime_Execute_ParallelSteps(t *testing.T) {
+	r := NewTestPorterRuntime(t)
+	rm, ran := setupParallelSteps(t, r, "")
+
+	err := r.Execute(rm)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"Create network", "Create database", "Deploy app"}, ran())
+	assert.Equal(t, "Deploy app", ran()[2], "the step after the parallel group should run after the steps in the group")
+	assert.Equal(t, map[string]string{"network-id": "net-123", "database-id": "db-456"}, rm.GetOutputs())
+
+	gotOutput := r.TestContext.GetOutput()
+	assert.Contains(t, gotOutput, "Running 2 steps in parallel")
+	assert.Contains(t, gotOutput, "[Create network] creating network\n")
+	assert.Contains(t, gotOutput, "[Create database] creating database\n", "partial lines should be flushed when the step completes")
+}
+
+func TestPorterRuntime_Execute_ParallelSteps_Failure(t *testing.T) {
+	r := NewTestPorterRuntime(t)
+	rm, ran := setupParallelSteps(t, r, "Create network")
+	mixins := r.mixins.(*mixin.TestMixinProvider)
+	mixins.RunErrors = []error{errors.New("database quota exceeded")}
+
+	err := r.Execute(rm)
+	require.EqualError(t, err, `mixin execution failed: step "Create database" failed: database quota exceeded`)
+
+	assert.NotContains(t, ran(), "Deploy app", "steps after a failed parallel group should not run")
+	assert.Contains(t, r.TestContext.GetOutput(), `Cancelled step "Create network"`)
+	assert.Empty(t, rm.GetOutputs(), "outputs from a failed parallel group should be discarded")
+}
+
+func TestPorterRuntime_Execute_ParallelSteps_ContinueOnError(t *testing.T) {
+	r := NewTestPorterRuntime(t)
+	rm, ran := setupParallelSteps(t, r, "Create network")
+	rm.Install[0].Parallel[1].ContinueOnError = true
+	mixins := r.mixins.(*mixin.TestMixinProvider)
+	mixins.RunErrors = []error{errors.New("database quota exceeded")}
+
+	err := r.Execute(rm)
+	require.NoError(t, err)
+
+	assert.Contains(t, ran(), "Deploy app")
+	gotOutput := r.TestContext.GetOutput()
+	assert.Contains(t, gotOutput, `[Create database] Step "Create database" failed, continuing because continueOnError is set: database quota exceeded`)
+	assert.NotContains(t, gotOutput, "Cancelled step")
+}
+
+func TestPrefixedWriter(t *testing.T) {
+	var out bytes.Buffer
+	var lock sync.Mutex
+	w := newPrefixedWriter(&out, &lock, "mystep")
+
+	w.Write([]byte("hello\nwor"))
+	assert.Equal(t, "[mystep] hello\n", out.String(), "only complete lines should be written")
+
+	w.Write([]byte("ld\n"))
+	w.Write([]byte("partial"))
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "[mystep] hello\n[mystep] world\n[mystep] partial\n", out.String())
+}
@@ -0,0 +1,106 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"get.porter.sh/porter/pkg/claims"
+	"get.porter.sh/porter/pkg/config"
+	"github.com/pkg/errors"
+)
+
+// loadProgress starts recording the steps that complete during the action and,
+// when the action is resumed, loads the steps that completed in the failed run.
+func (r *PorterRuntime) loadProgress() error {
+	action := string(r.RuntimeManifest.Action)
+	r.progress = claims.ActionProgress{Action: action}
+	r.resumeFrom = nil
+
+	if os.Getenv(config.EnvResume) == "true" {
+		c := r.RuntimeManifest.claim
+		if c == nil {
+			return errors.New("unable to resume the action, the claim from the failed run was not provided")
+		}
+
+		progress, err := claims.ReadActionProgress(*c)
+		if err != nil {
+			return errors.Wrap(err, "unable to resume the action")
+		}
+		if progress == nil {
+			return errors.Errorf("unable to resume the action, the failed run of %s did not record which steps completed", c.Name)
+		}
+		if progress.Action != action {
+			return errors.Errorf("unable to resume the action, the failed run of %s was a %s action, not %s", c.Name, progress.Action, action)
+		}
+
+		r.resumeFrom = progress
+		fmt.Fprintf(r.Out, "resuming the failed %s action, %d steps completed in the previous run\n", action, len(progress.Steps))
+	}
+
+	return r.writeProgress()
+}
+
+// resumeStep returns the outputs of the step with the specified id and description when
+// it completed in the failed run that is being resumed. The values of the outputs are read
+// from the bundle outputs of the failed run, because they are not recorded with the progress.
+// When an output can't be restored, the step and the steps after it are executed again.
+func (r *PorterRuntime) resumeStep(id string, description string) (map[string]string, bool) {
+	if r.resumeFrom == nil {
+		return nil, false
+	}
+
+	completed, ok := r.resumeFrom.GetCompletedStep(id, description)
+	if !ok {
+		return nil, false
+	}
+
+	outputs := make(map[string]string, len(completed.Outputs))
+	for _, name := range completed.Outputs {
+		value, ok := r.RuntimeManifest.claim.Outputs[name]
+		if !ok {
+			fmt.Fprintf(r.Out, "Executing step %q again, its output %s is not a bundle output of the previous run\n", description, name)
+			r.resumeFrom = nil
+			return nil, false
+		}
+		outputs[name] = fmt.Sprintf("%v", value)
+	}
+	return outputs, true
+}
+
+// recordCompletedStep records that a step completed, and the names of its outputs,
+// so that it can be skipped when a failed action is resumed.
+func (r *PorterRuntime) recordCompletedStep(id string, description string, outputs map[string]string) error {
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	r.progress.Steps = append(r.progress.Steps, claims.CompletedStep{
+		ID:          id,
+		Description: description,
+		Outputs:     names,
+	})
+	return r.writeProgress()
+}
+
+// writeProgress writes the progress of the action to a bundle output,
+// which Porter saves to the claim when the action completes.
+func (r *PorterRuntime) writeProgress() error {
+	err := r.createOutputsDir()
+	if err != nil {
+		return err
+	}
+
+	progressB, err := json.Marshal(r.progress)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal the progress of the action")
+	}
+
+	outpath := filepath.Join(config.BundleOutputsDir, claims.StepsOutput)
+	err = r.FileSystem.WriteFile(outpath, progressB, 0755)
+	return errors.Wrapf(err, "unable to write output file %s", outpath)
+}
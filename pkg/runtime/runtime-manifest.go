@@ -282,9 +282,6 @@ func (m *RuntimeManifest) buildSourceData() (map[string]interface{}, error) {
 	deps := make(map[string]interface{})
 	bun["dependencies"] = deps
 	for alias, bun := range m.bundles {
-		// TODO: Support bundle.dependencies.ALIAS.parameters.NAME
-
-		// bundle.dependencies.ALIAS.outputs.NAME
 		depBundle := make(map[string]interface{})
 		deps[alias] = depBundle
 
@@ -292,6 +289,28 @@ func (m *RuntimeManifest) buildSourceData() (map[string]interface{}, error) {
 		depBundle["version"] = bun.Version
 		depBundle["description"] = bun.Description
 
+		// bundle.dependencies.ALIAS.parameters.NAME
+		depParams := make(map[string]interface{})
+		depBundle["parameters"] = depParams
+		for name, param := range bun.Parameters {
+			value, ok, err := ReadDependencyParameterValue(m.Context, alias, name)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				// The parameter wasn't passed to the dependency
+				continue
+			}
+
+			depParams[name] = value
+
+			def := bun.Definitions[param.Definition]
+			if def != nil && def.WriteOnly != nil && *def.WriteOnly {
+				m.setSensitiveValue(value)
+			}
+		}
+
+		// bundle.dependencies.ALIAS.outputs.NAME
 		depOutputs := make(map[string]interface{})
 		depBundle["outputs"] = depOutputs
 
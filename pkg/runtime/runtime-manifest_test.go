@@ -623,14 +623,13 @@ func TestResolveMissingStepOutputs(t *testing.T) {
 }
 
 func TestResolveDependencyParam(t *testing.T) {
-	t.Skip("still haven't decided if this is going to be supported")
-
 	s := &manifest.Step{
 		Data: map[string]interface{}{
 			"helm": map[interface{}]interface{}{
 				"description": "install wordpress",
 				"Arguments": []string{
 					"{{bundle.dependencies.mysql.parameters.database}}",
+					"{{bundle.dependencies.mysql.parameters.password}}",
 				},
 			},
 		},
@@ -649,8 +648,22 @@ func TestResolveDependencyParam(t *testing.T) {
 		},
 	}
 	rm := NewRuntimeManifest(cxt.Context, manifest.ActionInstall, m)
+	rm.bundles = map[string]bundle.Bundle{
+		"mysql": {
+			Parameters: map[string]bundle.Parameter{
+				"database": {Definition: "database"},
+				"password": {Definition: "password"},
+			},
+			Definitions: map[string]*definition.Schema{
+				"database": {Type: "string"},
+				"password": {Type: "string", WriteOnly: makeBoolPtr(true)},
+			},
+		},
+	}
+
+	cxt.FileSystem.WriteFile("/cnab/app/dependencies/mysql/parameters/database", []byte("wordpress"), 0644)
+	cxt.FileSystem.WriteFile("/cnab/app/dependencies/mysql/parameters/password", []byte("topsecret"), 0644)
 
-	os.Setenv("DATABASE", "wordpress")
 	err := rm.ResolveStep(s)
 	require.NoError(t, err)
 	helm, ok := s.Data["helm"].(map[interface{}]interface{})
@@ -658,11 +671,13 @@ func TestResolveDependencyParam(t *testing.T) {
 	args, ok := helm["Arguments"].([]interface{})
 	assert.True(t, ok)
 	assert.Equal(t, "wordpress", args[0].(string))
+	assert.Equal(t, "topsecret", args[1].(string))
+
+	// Only the sensitive dependency parameter should be tracked
+	assert.Equal(t, []string{"topsecret"}, rm.GetSensitiveValues())
 }
 
 func TestResolveMissingDependencyParam(t *testing.T) {
-	t.Skip("still haven't decided if this is going to be supported")
-
 	s := &manifest.Step{
 		Data: map[string]interface{}{
 			"helm": map[interface{}]interface{}{
@@ -687,11 +702,19 @@ func TestResolveMissingDependencyParam(t *testing.T) {
 		},
 	}
 	rm := NewRuntimeManifest(cxt.Context, manifest.ActionInstall, m)
+	rm.bundles = map[string]bundle.Bundle{
+		"mysql": {
+			Parameters: map[string]bundle.Parameter{
+				"database": {Definition: "database"},
+			},
+		},
+	}
+
+	cxt.FileSystem.WriteFile("/cnab/app/dependencies/mysql/parameters/database", []byte("wordpress"), 0644)
 
-	os.Setenv("DATABASE", "wordpress")
 	err := rm.ResolveStep(s)
 	require.Error(t, err)
-	assert.Equal(t, "unable to resolve step: unable to render template values: Missing variable \"nope\"", err.Error())
+	assert.Contains(t, err.Error(), "Missing variable \"nope\"")
 }
 
 func TestManifest_ResolveBundleName(t *testing.T) {
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
+	"get.porter.sh/porter/pkg/claims"
 	"get.porter.sh/porter/pkg/config"
 	"get.porter.sh/porter/pkg/context"
 	"get.porter.sh/porter/pkg/manifest"
@@ -22,6 +25,12 @@ type PorterRuntime struct {
 	*context.Context
 	mixins          mixin.MixinProvider
 	RuntimeManifest *RuntimeManifest
+
+	// progress records the steps that completed during the action.
+	progress claims.ActionProgress
+
+	// resumeFrom is the progress of the failed run that is being resumed.
+	resumeFrom *claims.ActionProgress
 }
 
 func NewPorterRuntime(cxt *context.Context, mixins mixin.MixinProvider) *PorterRuntime {
@@ -67,59 +76,206 @@ func (r *PorterRuntime) Execute(rm *RuntimeManifest) error {
 		return errors.Wrapf(err, "could not create outputs directory %s", context.MixinOutputsDir)
 	}
 
-	for _, step := range r.RuntimeManifest.GetSteps() {
-		if step != nil {
-			err := r.RuntimeManifest.ResolveStep(step)
-			if err != nil {
-				return errors.Wrap(err, "unable to resolve step")
-			}
+	err = r.loadProgress()
+	if err != nil {
+		return err
+	}
 
-			description, _ := step.GetDescription()
-			fmt.Fprintln(r.Out, description)
+	for i, step := range r.RuntimeManifest.GetSteps() {
+		if step == nil {
+			continue
+		}
 
-			// Hand over values needing masking in context output streams
-			r.Context.SetSensitiveValues(r.RuntimeManifest.GetSensitiveValues())
+		// Identify steps by their position in the action, so that they can be skipped when resuming
+		id := strconv.Itoa(i + 1)
+		if step.IsParallel() {
+			err = r.executeParallelSteps(id, step)
+		} else {
+			err = r.executeStep(id, step)
+		}
+		if err != nil {
+			return err
+		}
+	}
 
-			input := &ActionInput{
-				action: r.RuntimeManifest.Action,
-				Steps:  []*manifest.Step{step},
-			}
-			inputBytes, _ := yaml.Marshal(input)
-			cmd := mixin.CommandOptions{
-				Command: string(r.RuntimeManifest.Action),
-				Input:   string(inputBytes),
-				Runtime: true,
-			}
-			err = r.mixins.Run(r.Context, step.GetMixinName(), cmd)
-			if err != nil {
-				return errors.Wrap(err, "mixin execution failed")
-			}
+	err = r.applyUnboundBundleOutputs()
+	if err != nil {
+		return err
+	}
 
-			outputs, err := r.readMixinOutputs()
-			if err != nil {
-				return errors.Wrap(err, "could not read step outputs")
-			}
+	fmt.Fprintln(r.Out, "execution completed successfully!")
+	return nil
+}
 
-			err = r.RuntimeManifest.ApplyStepOutputs(step, outputs)
-			if err != nil {
-				return err
-			}
+// executeStep executes a single step and applies its outputs.
+func (r *PorterRuntime) executeStep(id string, step *manifest.Step) error {
+	description, _ := step.GetDescription()
 
-			// Apply any Bundle Outputs declared in this step
-			err = r.applyStepOutputsToBundle(outputs)
-			if err != nil {
-				return err
-			}
+	if outputs, ok := r.resumeStep(id, description); ok {
+		fmt.Fprintf(r.Out, "Skipping step %q, it completed in the previous run\n", description)
+		return r.applyCompletedStep(id, description, step, outputs)
+	}
+	stepKey := description
+
+	run, err := r.RuntimeManifest.EvaluateCondition(step)
+	if err != nil {
+		return errors.Wrapf(err, "unable to evaluate the condition of step %q", description)
+	}
+	if !run {
+		fmt.Fprintf(r.Out, "Skipping step %q, its condition %q is not true\n", description, step.If)
+		return nil
+	}
+
+	err = r.RuntimeManifest.ResolveStep(step)
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve step")
+	}
+
+	description, _ = step.GetDescription()
+	fmt.Fprintln(r.Out, description)
+
+	// Hand over values needing masking in context output streams
+	r.Context.SetSensitiveValues(r.RuntimeManifest.GetSensitiveValues())
+
+	err = r.runStep(stepRun{Context: r.Context, step: step, description: description})
+	if err != nil {
+		// Discard any outputs from the failed step
+		if _, cleanupErr := r.readMixinOutputs(); cleanupErr != nil {
+			return errors.Wrap(cleanupErr, "could not clean up step outputs")
 		}
+
+		if !step.ContinueOnError {
+			return errors.Wrap(err, "mixin execution failed")
+		}
+		fmt.Fprintf(r.Out, "Step %q failed, continuing because continueOnError is set: %s\n", description, err)
+		return nil
 	}
 
-	err = r.applyUnboundBundleOutputs()
+	return r.completeStep(id, stepKey, step)
+}
+
+// completeStep reads the outputs written by the mixins for a step that completed,
+// applies them, and records that the step completed.
+func (r *PorterRuntime) completeStep(id string, description string, step *manifest.Step) error {
+	outputs, err := r.readMixinOutputs()
+	if err != nil {
+		return errors.Wrap(err, "could not read step outputs")
+	}
+
+	return r.applyCompletedStep(id, description, step, outputs)
+}
+
+// applyCompletedStep applies the outputs of a completed step to the runtime
+// manifest and the bundle outputs, and records that the step completed.
+func (r *PorterRuntime) applyCompletedStep(id string, description string, step *manifest.Step, outputs map[string]string) error {
+	err := r.RuntimeManifest.ApplyStepOutputs(step, outputs)
 	if err != nil {
 		return err
 	}
 
-	fmt.Fprintln(r.Out, "execution completed successfully!")
-	return nil
+	// Apply any Bundle Outputs declared in this step
+	err = r.applyStepOutputsToBundle(outputs)
+	if err != nil {
+		return err
+	}
+
+	return r.recordCompletedStep(id, description, outputs)
+}
+
+// stepRun is a single execution of a step by its mixin.
+type stepRun struct {
+	// Context is used by the mixin and for reporting the attempts of the step.
+	*context.Context
+
+	step        *manifest.Step
+	description string
+
+	// cancel stops the step when it is closed.
+	cancel <-chan struct{}
+
+	// parallel indicates that other steps are running at the same time, and
+	// writing their outputs to the same directory.
+	parallel bool
+}
+
+// errStepCancelled is returned when a step is stopped because another step failed.
+var errStepCancelled = errors.New("step was cancelled")
+
+// runStep runs a step with its mixin, retrying the step when it fails
+// and stopping any attempt that runs longer than the step's timeout.
+func (r *PorterRuntime) runStep(run stepRun) error {
+	step := run.step
+	retryDelay, err := step.GetRetryDelay()
+	if err != nil {
+		return err
+	}
+	timeout, err := step.GetTimeout()
+	if err != nil {
+		return err
+	}
+
+	input := &ActionInput{
+		action: r.RuntimeManifest.Action,
+		Steps:  []*manifest.Step{step.GetMixinStep()},
+	}
+	inputBytes, _ := yaml.Marshal(input)
+	cmd := mixin.CommandOptions{
+		Command: string(r.RuntimeManifest.Action),
+		Input:   string(inputBytes),
+		Runtime: true,
+		Timeout: timeout,
+		Cancel:  run.cancel,
+	}
+
+	attempts := step.Retries + 1
+	for attempt := 1; ; attempt++ {
+		if attempts > 1 {
+			fmt.Fprintf(run.Out, "Running step %q, attempt %d of %d\n", run.description, attempt, attempts)
+		}
+
+		// The mixin runner enforces the timeout and cancellation, and stops the mixin before returning
+		err = r.mixins.Run(run.Context, step.GetMixinName(), cmd)
+		if err == nil {
+			return nil
+		}
+
+		if isCancelled(run.cancel) {
+			return errStepCancelled
+		}
+
+		if attempt >= attempts {
+			if attempts > 1 {
+				return errors.Wrapf(err, "step failed after %d attempts", attempts)
+			}
+			return err
+		}
+
+		// Discard any outputs from the failed attempt, unless other steps are writing outputs too
+		if !run.parallel {
+			if _, cleanupErr := r.readMixinOutputs(); cleanupErr != nil {
+				return errors.Wrap(cleanupErr, "could not clean up step outputs")
+			}
+		}
+
+		fmt.Fprintf(run.Out, "Attempt %d of %d of step %q failed: %s\n", attempt, attempts, run.description, err)
+		if retryDelay > 0 {
+			fmt.Fprintf(run.Out, "Retrying in %s\n", retryDelay)
+			select {
+			case <-time.After(retryDelay):
+			case <-run.cancel:
+				return errStepCancelled
+			}
+		}
+	}
+}
+
+func isCancelled(cancel <-chan struct{}) bool {
+	select {
+	case <-cancel:
+		return true
+	default:
+		return false
+	}
 }
 
 func (r *PorterRuntime) createOutputsDir() error {
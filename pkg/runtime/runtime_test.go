@@ -2,15 +2,22 @@ package runtime
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"get.porter.sh/porter/pkg/claims"
 	"get.porter.sh/porter/pkg/config"
 	"get.porter.sh/porter/pkg/context"
 	"get.porter.sh/porter/pkg/manifest"
+	"get.porter.sh/porter/pkg/mixin"
 	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/cnabio/cnab-go/claim"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 func TestPorterRuntime_Execute_readMixinOutputs(t *testing.T) {
@@ -303,3 +310,282 @@ func TestLoadImageMappingFilesGoodFiles(t *testing.T) {
 	assert.NotEmpty(t, reloMap)
 	assert.Equal(t, "mysql", bun.Name)
 }
+
+func TestPorterRuntime_Execute_SkipsConditionalSteps(t *testing.T) {
+	testcases := []struct {
+		backup    string
+		wantSteps []string
+	}{
+		{"true", []string{"Install Hello World", "Backup Hello World"}},
+		{"false", []string{"Install Hello World"}},
+	}
+
+	for _, tc := range testcases {
+		t.Run("backup="+tc.backup, func(t *testing.T) {
+			os.Setenv("BACKUP", tc.backup)
+			defer os.Unsetenv("BACKUP")
+
+			r := NewTestPorterRuntime(t)
+			r.TestContext.AddTestFile("testdata/conditional-steps.yaml", config.Name)
+			r.TestContext.AddTestFile("testdata/bundle-images.json", "/cnab/bundle.json")
+
+			var ranSteps []string
+			mixins := r.mixins.(*mixin.TestMixinProvider)
+			mixins.RunAssertions = append(mixins.RunAssertions, func(mixinCxt *context.Context, mixinName string, commandOpts mixin.CommandOptions) {
+				assert.NotContains(t, commandOpts.Input, "if:", "the step condition should not be passed to the mixin")
+
+				var input map[string][]manifest.Step
+				require.NoError(t, yaml.Unmarshal([]byte(commandOpts.Input), &input))
+				for _, step := range input["install"] {
+					description, _ := step.GetDescription()
+					ranSteps = append(ranSteps, description)
+				}
+			})
+
+			m, err := manifest.LoadManifestFrom(r.Context, config.Name)
+			require.NoError(t, err)
+			rm := NewRuntimeManifest(r.Context, manifest.ActionInstall, m)
+
+			err = r.Execute(rm)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantSteps, ranSteps)
+
+			gotOutput := r.TestContext.GetOutput()
+			if len(tc.wantSteps) == 1 {
+				assert.Contains(t, gotOutput, `Skipping step "Backup Hello World", its condition "{{ bundle.parameters.backup }}" is not true`)
+			} else {
+				assert.NotContains(t, gotOutput, "Skipping step")
+			}
+		})
+	}
+}
+
+func TestPorterRuntime_Execute_StepPolicies(t *testing.T) {
+	flakyErr := errors.New("the cloud API is unavailable")
+
+	testcases := []struct {
+		name        string
+		configure   func(step *manifest.Step)
+		runErrors   []error
+		wantTimeout time.Duration
+		wantErr     string
+		wantRuns    int
+		wantOutput  []string
+	}{
+		{
+			name:      "no retries",
+			runErrors: []error{flakyErr},
+			wantErr:   "mixin execution failed: the cloud API is unavailable",
+			wantRuns:  1,
+		},
+		{
+			name: "retry until success",
+			configure: func(step *manifest.Step) {
+				step.Retries = 2
+				step.RetryDelay = "1ms"
+			},
+			runErrors: []error{flakyErr, flakyErr},
+			wantRuns:  4,
+			wantOutput: []string{
+				`Running step "Call a flaky API", attempt 1 of 3`,
+				`Attempt 1 of 3 of step "Call a flaky API" failed: the cloud API is unavailable`,
+				"Retrying in 1ms",
+				`Attempt 2 of 3 of step "Call a flaky API" failed: the cloud API is unavailable`,
+				`Running step "Call a flaky API", attempt 3 of 3`,
+				"execution completed successfully!",
+			},
+		},
+		{
+			name: "retries exhausted",
+			configure: func(step *manifest.Step) {
+				step.Retries = 1
+			},
+			runErrors: []error{flakyErr, flakyErr},
+			wantErr:   "mixin execution failed: step failed after 2 attempts: the cloud API is unavailable",
+			wantRuns:  2,
+		},
+		{
+			name: "continue on error",
+			configure: func(step *manifest.Step) {
+				step.ContinueOnError = true
+			},
+			runErrors: []error{flakyErr},
+			wantRuns:  2,
+			wantOutput: []string{
+				`Step "Call a flaky API" failed, continuing because continueOnError is set: the cloud API is unavailable`,
+				"execution completed successfully!",
+			},
+		},
+		{
+			name: "timeout",
+			configure: func(step *manifest.Step) {
+				step.Timeout = "10ms"
+			},
+			runErrors:   []error{errors.New("mixin command exec timed out after 10ms")},
+			wantTimeout: 10 * time.Millisecond,
+			wantErr:     "mixin execution failed: mixin command exec timed out after 10ms",
+			wantRuns:    1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewTestPorterRuntime(t)
+			r.TestContext.AddTestFile("testdata/step-policies.yaml", config.Name)
+			r.TestContext.AddTestFile("testdata/bundle-images.json", "/cnab/bundle.json")
+
+			runs := 0
+			mixins := r.mixins.(*mixin.TestMixinProvider)
+			mixins.RunErrors = tc.runErrors
+			mixins.RunAssertions = append(mixins.RunAssertions, func(mixinCxt *context.Context, mixinName string, commandOpts mixin.CommandOptions) {
+				runs++
+				assert.NotContains(t, commandOpts.Input, "retries", "the step policies should not be passed to the mixin")
+				assert.Equal(t, tc.wantTimeout, commandOpts.Timeout, "the timeout should be enforced by the mixin runner")
+			})
+
+			m, err := manifest.LoadManifestFrom(r.Context, config.Name)
+			require.NoError(t, err)
+			if tc.configure != nil {
+				tc.configure(m.Install[0])
+			}
+			rm := NewRuntimeManifest(r.Context, manifest.ActionInstall, m)
+
+			err = r.Execute(rm)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantRuns, runs, "unexpected number of mixin runs")
+
+			gotOutput := r.TestContext.GetOutput()
+			for _, wantOutput := range tc.wantOutput {
+				assert.Contains(t, gotOutput, wantOutput)
+			}
+		})
+	}
+}
+
+func TestPorterRuntime_Execute_Resume(t *testing.T) {
+	os.Setenv(config.EnvResume, "true")
+	defer os.Unsetenv(config.EnvResume)
+
+	r := NewTestPorterRuntime(t)
+	r.TestContext.AddTestFile("testdata/resume-steps.yaml", config.Name)
+	r.TestContext.AddTestFile("testdata/bundle-images.json", "/cnab/bundle.json")
+
+	// The claim from the failed run, which recorded that the first step completed
+	c := claim.Claim{
+		Name:    "mydb",
+		Outputs: map[string]interface{}{"host": "db.example.com"},
+		Custom: map[string]interface{}{
+			claims.StepsKey: claims.ActionProgress{
+				Action: "install",
+				Steps: []claims.CompletedStep{
+					{ID: "1", Description: "Create the database", Outputs: []string{"host"}},
+				},
+			},
+		},
+	}
+	claimB, err := yaml.Marshal(c)
+	require.NoError(t, err)
+	require.NoError(t, r.FileSystem.WriteFile(config.ClaimFilepath, claimB, 0644))
+
+	var ranSteps []string
+	mixins := r.mixins.(*mixin.TestMixinProvider)
+	mixins.RunAssertions = append(mixins.RunAssertions, func(mixinCxt *context.Context, mixinName string, commandOpts mixin.CommandOptions) {
+		assert.Contains(t, commandOpts.Input, "./configure-db.sh db.example.com", "the outputs of the completed step should be interpolated")
+
+		var input map[string][]manifest.Step
+		require.NoError(t, yaml.Unmarshal([]byte(commandOpts.Input), &input))
+		for _, step := range input["install"] {
+			description, _ := step.GetDescription()
+			ranSteps = append(ranSteps, description)
+		}
+	})
+
+	m, err := manifest.LoadManifestFrom(r.Context, config.Name)
+	require.NoError(t, err)
+	rm := NewRuntimeManifest(r.Context, manifest.ActionInstall, m)
+
+	err = r.Execute(rm)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Configure the database"}, ranSteps)
+
+	gotOutput := r.TestContext.GetOutput()
+	assert.Contains(t, gotOutput, "resuming the failed install action, 1 steps completed in the previous run")
+	assert.Contains(t, gotOutput, `Skipping step "Create the database", it completed in the previous run`)
+
+	progressB, err := r.FileSystem.ReadFile(filepath.Join(config.BundleOutputsDir, claims.StepsOutput))
+	require.NoError(t, err)
+	progress, err := claims.ParseActionProgress(string(progressB))
+	require.NoError(t, err)
+	require.Len(t, progress.Steps, 2, "both steps should be recorded as completed")
+	assert.Equal(t, []string{"host"}, progress.Steps[0].Outputs, "only the names of the outputs should be recorded")
+	assert.Equal(t, "Configure the database", progress.Steps[1].Description)
+	assert.NotContains(t, string(progressB), "db.example.com", "the values of the outputs should not be recorded")
+}
+
+func TestPorterRuntime_Execute_ResumeOutputNotRecorded(t *testing.T) {
+	os.Setenv(config.EnvResume, "true")
+	defer os.Unsetenv(config.EnvResume)
+
+	r := NewTestPorterRuntime(t)
+	r.TestContext.AddTestFile("testdata/resume-steps.yaml", config.Name)
+	r.TestContext.AddTestFile("testdata/bundle-images.json", "/cnab/bundle.json")
+
+	// The output of the completed step is not a bundle output, so it was not saved on the claim
+	c := claim.Claim{
+		Name: "mydb",
+		Custom: map[string]interface{}{
+			claims.StepsKey: claims.ActionProgress{
+				Action: "install",
+				Steps: []claims.CompletedStep{
+					{ID: "1", Description: "Create the database", Outputs: []string{"host"}},
+				},
+			},
+		},
+	}
+	claimB, err := yaml.Marshal(c)
+	require.NoError(t, err)
+	require.NoError(t, r.FileSystem.WriteFile(config.ClaimFilepath, claimB, 0644))
+
+	var ranSteps []string
+	mixins := r.mixins.(*mixin.TestMixinProvider)
+	mixins.RunAssertions = append(mixins.RunAssertions, func(mixinCxt *context.Context, mixinName string, commandOpts mixin.CommandOptions) {
+		var input map[string][]manifest.Step
+		require.NoError(t, yaml.Unmarshal([]byte(commandOpts.Input), &input))
+		for _, step := range input["install"] {
+			description, _ := step.GetDescription()
+			ranSteps = append(ranSteps, description)
+			if description == "Create the database" {
+				mixinCxt.WriteMixinOutputToFile("host", []byte("db.example.com"))
+			}
+		}
+	})
+
+	m, err := manifest.LoadManifestFrom(r.Context, config.Name)
+	require.NoError(t, err)
+	rm := NewRuntimeManifest(r.Context, manifest.ActionInstall, m)
+
+	err = r.Execute(rm)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Create the database", "Configure the database"}, ranSteps, "the step should be executed again when its outputs can't be restored")
+	assert.Contains(t, r.TestContext.GetOutput(), `Executing step "Create the database" again, its output host is not a bundle output of the previous run`)
+}
+
+func TestPorterRuntime_Execute_ResumeWithoutProgress(t *testing.T) {
+	os.Setenv(config.EnvResume, "true")
+	defer os.Unsetenv(config.EnvResume)
+
+	r := NewTestPorterRuntime(t)
+	r.TestContext.AddTestFile("testdata/resume-steps.yaml", config.Name)
+	r.TestContext.AddTestFile("testdata/bundle-images.json", "/cnab/bundle.json")
+
+	m, err := manifest.LoadManifestFrom(r.Context, config.Name)
+	require.NoError(t, err)
+	rm := NewRuntimeManifest(r.Context, manifest.ActionInstall, m)
+
+	err = r.Execute(rm)
+	require.EqualError(t, err, "unable to resume the action, the claim from the failed run was not provided")
+}